@@ -0,0 +1,60 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"strings"
+)
+
+// anonymizeDigits fills dst with digits derived from HMAC-SHA256 of pan
+func anonymizeDigits(key []byte, pan string, counter byte, dst []byte) {
+	h := hmac.New(sha256.New, key)
+	var block byte
+	for n := 0; n < len(dst); block++ {
+		h.Reset()
+		h.Write([]byte("creditcard anonymize"))
+		h.Write([]byte{counter, block})
+		h.Write([]byte(pan))
+		for _, b := range h.Sum(nil) {
+			// discard 250-255 to avoid bias
+			if b >= 250 {
+				continue
+			}
+			dst[n] = '0' + b%10
+			if n++; n == len(dst) {
+				return
+			}
+		}
+	}
+}
+
+func (i *info) Anonymize(key []byte) (ret Info, err error) {
+	if i.IsZero() {
+		return nil, ErrNoCard
+	}
+	pan := i.RawPAN()
+	if strings.Contains(pan, "*") {
+		err = ErrMaskedPAN
+		return
+	}
+
+	l := len(pan)
+	buf := []byte(pan)
+	// last digit of middle part is 4th digit from the check digit, it is
+	// never doubled in luhn, so it can be computed directly
+	fix := l - 5
+	for counter := byte(0); ; counter++ {
+		anonymizeDigits(key, pan, counter, buf[6:fix])
+		buf[fix] = '0'
+		buf[fix] = '0' + byte((10-luhnSum(buf)%10)%10)
+		if string(buf) != pan {
+			break
+		}
+	}
+
+	return i.config().fromRaw(string(buf))
+}
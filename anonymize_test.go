@@ -0,0 +1,61 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAnonymize(t *testing.T) {
+	key := []byte("staging")
+	pans := []string{"4111111111111111", "5555555555554444", "3530111333300000"}
+	for i := 0; i < 200; i++ {
+		pans = append(pans, fmt.Sprintf("4%015d", i*104729))
+	}
+
+	for _, pan := range pans {
+		t.Run(pan, func(t *testing.T) {
+			info, _ := FromRaw(pan)
+			a, err := info.Anonymize(key)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			b, _ := info.Anonymize(key)
+			c, _ := info.Anonymize([]byte("other"))
+			actual := a.RawPAN()
+
+			if actual != b.RawPAN() {
+				t.Log("first: ", actual)
+				t.Log("second:", b.RawPAN())
+				t.Fatal("not deterministic")
+			}
+			if actual == c.RawPAN() {
+				t.Fatal("different key should produce different result")
+			}
+			if actual == pan {
+				t.Fatal("result is same as input")
+			}
+			if a.First6() != info.First6() || a.Last4() != info.Last4() ||
+				len(actual) != len(pan) || a.CardType() != info.CardType() {
+				t.Log("expect:", pan)
+				t.Log("actual:", actual)
+				t.Fatal("structure is not preserved")
+			}
+			if !luhnValid([]byte(actual)) {
+				t.Fatal("result does not pass luhn check:", actual)
+			}
+		})
+	}
+}
+
+func TestAnonymizeMasked(t *testing.T) {
+	info, _ := FromMasked("411111", "1111")
+	if _, err := info.Anonymize([]byte("key")); err != ErrMaskedPAN {
+		t.Log("expect:", ErrMaskedPAN)
+		t.Log("actual:", err)
+		t.Fatal("unexpected error")
+	}
+}
@@ -0,0 +1,37 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"errors"
+	"strconv"
+)
+
+func (i *info) AuditString() (ret string) {
+	if i.IsZero() {
+		return
+	}
+	brand := auditBrand(i.CardType())
+
+	status := "valid"
+	switch err := i.validate(); {
+	case err == nil:
+	case errors.Is(err, ErrValidateMasked):
+		status = "masked"
+	default:
+		status = "invalid"
+	}
+
+	return brand + "|" + i.First6() + "|" + i.Last4() + "|" +
+		strconv.Itoa(len(i.RawPAN())) + "|" + status
+}
+
+// auditBrand returns brand name of t used in AuditString
+func auditBrand(t CardType) (ret string) {
+	if ret = brands[t].audit; ret == "" {
+		ret = "UNKNOWN"
+	}
+	return
+}
@@ -0,0 +1,32 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import "testing"
+
+func TestAuditString(t *testing.T) {
+	cases := map[string]string{
+		"4111111111000066": "VISA|411111|0066|16|valid",
+		"4111111111000067": "VISA|411111|0067|16|invalid",
+		"411111******0066": "VISA|411111|0066|16|masked",
+		"3528************": "JCB|3528**|****|16|masked",
+		"****************": "UNKNOWN|******|****|16|masked",
+		"0000000000000000": "UNKNOWN|000000|0000|16|valid",
+	}
+
+	for pan, expect := range cases {
+		t.Run(pan, func(t *testing.T) {
+			info, err := FromRaw(pan)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if actual := info.AuditString(); actual != expect {
+				t.Log("expect:", expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+		})
+	}
+}
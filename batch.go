@@ -0,0 +1,124 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"context"
+	"sync"
+)
+
+// Result is the validation result of a PAN in batch validation
+type Result struct {
+	Index    int      // index of the PAN in input, starts from 0
+	Info     Info     // masked info, nil if failed to parse
+	CardType CardType // card type, UnknownCardType if failed to parse
+	Err      error    // error from FromRaw or Validate
+}
+
+func validateOne(idx int, pan string) (ret Result) {
+	ret = Result{Index: idx, CardType: UnknownCardType}
+	info, err := FromRaw(pan)
+	if err != nil {
+		ret.Err = err
+		return
+	}
+
+	ret.Err = info.Validate()
+	ret.CardType = info.CardType()
+	ret.Info, _ = defaultConfig.fromRaw(info.RawMasked())
+	return
+}
+
+type batchJob struct {
+	idx  int
+	pans []string
+}
+
+// max number of pans sent to a worker at once, reduces channel overhead
+const batchChunkSize = 64
+
+// ValidateConcurrent validates raw PANs read from in with a pool of workers
+//
+// Results are sent in no particular order, use Result.Index to match it with
+// input. The returned channel is closed after in is closed and all PANs are
+// processed, or ctx is done. You MUST cancel ctx if you stop reading results
+// before the channel is closed, or worker goroutines will leak.
+//
+// workers < 1 is treated as 1.
+func ValidateConcurrent(ctx context.Context, in <-chan string, workers int) (ret <-chan Result) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	out := make(chan Result, workers)
+	jobs := make(chan batchJob)
+	wg := &sync.WaitGroup{}
+
+	go func() {
+		defer close(jobs)
+		idx := 0
+		for {
+			var pan string
+			var ok bool
+			select {
+			case <-ctx.Done():
+				return
+			case pan, ok = <-in:
+				if !ok {
+					return
+				}
+			}
+
+			// take whatever is immediately available to fill the chunk
+			chunk := append(make([]string, 0, batchChunkSize), pan)
+		fill:
+			for len(chunk) < batchChunkSize {
+				select {
+				case pan, ok = <-in:
+					if !ok {
+						break fill
+					}
+					chunk = append(chunk, pan)
+				default:
+					break fill
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case jobs <- batchJob{idx: idx, pans: chunk}:
+			}
+			idx += len(chunk)
+			if !ok {
+				return
+			}
+		}
+	}()
+
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				for i, pan := range j.pans {
+					r := validateOne(j.idx+i, pan)
+					select {
+					case <-ctx.Done():
+						return
+					case out <- r:
+					}
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
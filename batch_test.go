@@ -0,0 +1,142 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func batchInput(n int) (ret []string) {
+	ret = make([]string, n)
+	for i := range ret {
+		switch i % 3 {
+		case 0:
+			ret[i] = fmt.Sprintf("4%015d", i)
+		case 1:
+			ret[i] = fmt.Sprintf("5%015d", i*7)
+		default:
+			ret[i] = "bad" + strconv.Itoa(i)
+		}
+	}
+	return
+}
+
+func feed(ctx context.Context, pans []string) (ret <-chan string) {
+	ch := make(chan string)
+	go func() {
+		defer close(ch)
+		for _, p := range pans {
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- p:
+			}
+		}
+	}()
+	return ch
+}
+
+func waitGoroutines(t *testing.T, expect int) {
+	for i := 0; i < 100; i++ {
+		if runtime.NumGoroutine() <= expect {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Log("expect:", expect)
+	t.Log("actual:", runtime.NumGoroutine())
+	t.Fatal("goroutine leaked")
+}
+
+func TestValidateConcurrent(t *testing.T) {
+	pans := batchInput(1000)
+	for _, workers := range []int{0, 1, 3, 8} {
+		t.Run(strconv.Itoa(workers), func(t *testing.T) {
+			ch := ValidateConcurrent(context.Background(), feed(context.Background(), pans), workers)
+			results := make([]*Result, len(pans))
+			for r := range ch {
+				r := r
+				if results[r.Index] != nil {
+					t.Fatal("duplicated index:", r.Index)
+				}
+				results[r.Index] = &r
+			}
+
+			for idx, r := range results {
+				if r == nil {
+					t.Fatal("missing index:", idx)
+				}
+				expect := validateOne(idx, pans[idx])
+				if !reflect.DeepEqual(r.Err, expect.Err) || r.CardType != expect.CardType {
+					t.Logf("expect: %+v", expect)
+					t.Logf("actual: %+v", *r)
+					t.Fatal("unexpected result")
+				}
+				if expect.Info == nil {
+					if r.Info != nil {
+						t.Fatal("unexpected info for unparsable pan:", idx)
+					}
+					continue
+				}
+				if r.Info.RawPAN() != expect.Info.RawMasked() {
+					t.Log("expect:", expect.Info.RawMasked())
+					t.Log("actual:", r.Info.RawPAN())
+					t.Fatal("result is not masked")
+				}
+			}
+		})
+	}
+}
+
+func TestValidateConcurrentCancel(t *testing.T) {
+	base := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// endless input which is never closed
+	in := make(chan string)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case in <- "4000000000000002":
+			}
+		}
+	}()
+
+	ch := ValidateConcurrent(ctx, in, 4)
+	for i := 0; i < 100; i++ {
+		<-ch
+	}
+	// stop reading in the middle of stream
+	cancel()
+
+	waitGoroutines(t, base)
+}
+
+func BenchmarkValidateConcurrent(b *testing.B) {
+	pans := batchInput(1000)
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(strconv.Itoa(workers), func(b *testing.B) {
+			ctx := context.Background()
+			in := make(chan string, 1024)
+			go func() {
+				defer close(in)
+				for i := 0; i < b.N; i++ {
+					in <- pans[i%len(pans)]
+				}
+			}()
+			b.ResetTimer()
+			for range ValidateConcurrent(ctx, in, workers) {
+			}
+		})
+	}
+}
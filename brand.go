@@ -0,0 +1,420 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"sort"
+	"strconv"
+)
+
+// iinRange matches PANs starting with lo-hi (both are digits long)
+type iinRange struct {
+	digits int
+	lo, hi int
+}
+
+// brand describes a built-in card type, see brands
+type brand struct {
+	name      string     // returned by CardType.String, DO NOT change it
+	audit     string     // used in AuditString, DO NOT change it
+	ranges    []iinRange // IIN ranges, see prefixRanges
+	lengths   []int      // lengths of PAN in ascending order
+	gaps      []int      // see Hints
+	cvcName   string     // see Hints
+	cvcLength int        // see Hints, 0 if there's none
+	ui        BrandUI    // see TypeInfo
+}
+
+// brands is the single source of data about built-in card types
+//
+// Detection (prefixRanges), lengths accepted by constructors
+// (variableLengths), validation (BrandLength), names, AuditString, Hints and
+// BrandUI are all derived from it. A brand without ranges, like Cartes
+// Bancaires, is detected only if enabled by an Option.
+var brands = map[CardType]brand{
+	VISACard: {
+		name:  "visa",
+		audit: "VISA",
+		ranges: []iinRange{
+			{1, 4, 4},
+		},
+		lengths:   []int{13, 16, 19},
+		gaps:      []int{4, 8, 12},
+		cvcName:   "CVV",
+		cvcLength: 3,
+		ui:        BrandUI{PrimaryColorHex: "#1A1F71", LogoSlug: "visa"},
+	},
+	MasterCard: {
+		name:  "mastercard",
+		audit: "MASTERCARD",
+		ranges: []iinRange{
+			{2, 51, 55},
+			// 2-series, 2300-2699 are not detected yet
+			{4, 2221, 2299},
+			{4, 2700, 2720},
+		},
+		lengths:   []int{16},
+		gaps:      []int{4, 8, 12},
+		cvcName:   "CVC",
+		cvcLength: 3,
+		ui:        BrandUI{PrimaryColorHex: "#EB001B", LogoSlug: "mastercard"},
+	},
+	JCBCard: {
+		name:  "jcb",
+		audit: "JCB",
+		ranges: []iinRange{
+			{4, 3528, 3589},
+		},
+		lengths:   []int{16, 17, 18, 19},
+		gaps:      []int{4, 8, 12},
+		cvcName:   "CVV",
+		cvcLength: 3,
+		ui:        BrandUI{PrimaryColorHex: "#0E4C96", LogoSlug: "jcb"},
+	},
+	AmericanExpress: {
+		name:  "amex",
+		audit: "AMEX",
+		ranges: []iinRange{
+			{2, 34, 34},
+			{2, 37, 37},
+		},
+		lengths:   []int{15},
+		gaps:      []int{4, 10},
+		cvcName:   "CID",
+		cvcLength: 4,
+		ui:        BrandUI{PrimaryColorHex: "#006FCF", LogoSlug: "amex"},
+	},
+	UnionPay: {
+		name:  "unionpay",
+		audit: "UNIONPAY",
+		ranges: []iinRange{
+			{2, 62, 62},
+			{2, 81, 81},
+		},
+		lengths:   []int{16, 17, 18, 19},
+		gaps:      []int{4, 8, 12},
+		cvcName:   "CVN",
+		cvcLength: 3,
+		ui:        BrandUI{PrimaryColorHex: "#E21836", LogoSlug: "unionpay"},
+	},
+	DinersClub: {
+		name:  "diners",
+		audit: "DINERS",
+		ranges: []iinRange{
+			{3, 300, 305},
+			{4, 3095, 3095},
+			{2, 36, 36},
+			{2, 38, 39},
+		},
+		lengths:   []int{14, 16, 19},
+		gaps:      []int{4, 10},
+		cvcName:   "CVV",
+		cvcLength: 3,
+		ui:        BrandUI{PrimaryColorHex: "#0079BE", LogoSlug: "diners"},
+	},
+	MaestroCard: {
+		name:  "maestro",
+		audit: "MAESTRO",
+		ranges: []iinRange{
+			// 51-55 are MasterCard, 60 is RuPay, 62 is UnionPay, Discover (6011,
+			// 644-649, 65) is not supported
+			{2, 50, 50},
+			{2, 56, 59},
+			{2, 61, 61},
+			{2, 63, 63},
+			{3, 640, 643},
+			{2, 66, 69},
+		},
+		lengths:   []int{12, 13, 14, 15, 16, 17, 18, 19},
+		gaps:      []int{4, 8, 12},
+		cvcName:   "CVC",
+		cvcLength: 3,
+		ui:        BrandUI{PrimaryColorHex: "#0099DF", LogoSlug: "maestro"},
+	},
+	MirCard: {
+		name:  "mir",
+		audit: "MIR",
+		ranges: []iinRange{
+			{4, 2200, 2204},
+		},
+		lengths:   []int{16, 17, 18, 19},
+		gaps:      []int{4, 8, 12},
+		cvcName:   "CVP2",
+		cvcLength: 3,
+		ui:        BrandUI{PrimaryColorHex: "#0F754E", LogoSlug: "mir"},
+	},
+	RuPayCard: {
+		name:  "rupay",
+		audit: "RUPAY",
+		ranges: []iinRange{
+			// 508 takes precedence over Maestro, and 60 is RuPay except Discover
+			// 6011. 81 is shared with UnionPay, which keeps 8100-8171.
+			{3, 508, 508},
+			{4, 6000, 6010},
+			{4, 6012, 6099},
+			{4, 6521, 6522},
+			{4, 8172, 8199},
+			{2, 82, 82},
+		},
+		lengths:   []int{16},
+		gaps:      []int{4, 8, 12},
+		cvcName:   "CVV",
+		cvcLength: 3,
+		ui:        BrandUI{PrimaryColorHex: "#F58220", LogoSlug: "rupay"},
+	},
+	EloCard: {
+		name:  "elo",
+		audit: "ELO",
+		ranges: []iinRange{
+			// Elo BINs, which take precedence over VISA, Maestro and UnionPay
+			{6, 401178, 401179},
+			{6, 431274, 431274},
+			{6, 438935, 438935},
+			{6, 451416, 451416},
+			{6, 457393, 457393},
+			{6, 457631, 457632},
+			{6, 504175, 504175},
+			{6, 506699, 506778},
+			{6, 509000, 509999},
+			{6, 627780, 627780},
+			{6, 636297, 636297},
+			{6, 636368, 636368},
+			{6, 650031, 650051},
+			{6, 650405, 650439},
+			{6, 650485, 650538},
+			{6, 650541, 650598},
+			{6, 650700, 650718},
+			{6, 650720, 650727},
+			{6, 650901, 650978},
+			{4, 6516, 6516},
+			{6, 655000, 655058},
+		},
+		lengths:   []int{16},
+		gaps:      []int{4, 8, 12},
+		cvcName:   "CVE",
+		cvcLength: 3,
+		ui:        BrandUI{PrimaryColorHex: "#FFCB05", LogoSlug: "elo"},
+	},
+	HipercardCard: {
+		name:  "hipercard",
+		audit: "HIPERCARD",
+		ranges: []iinRange{
+			// Hipercard, which take precedence over RuPay and Diners Club
+			{6, 606282, 606282},
+			{4, 3841, 3841},
+		},
+		lengths:   []int{16},
+		gaps:      []int{4, 8, 12},
+		cvcName:   "CVC",
+		cvcLength: 3,
+		ui:        BrandUI{PrimaryColorHex: "#B3131B", LogoSlug: "hipercard"},
+	},
+	DankortCard: {
+		name:  "dankort",
+		audit: "DANKORT",
+		ranges: []iinRange{
+			// Dankort, which takes precedence over Maestro. Visa co-badged ones
+			// (4571) are VISA unless WithDankortCoBadge is used.
+			{4, 5019, 5019},
+		},
+		lengths:   []int{16},
+		gaps:      []int{4, 8, 12},
+		cvcName:   "CVV",
+		cvcLength: 3,
+		ui:        BrandUI{PrimaryColorHex: "#ED1C24", LogoSlug: "dankort"},
+	},
+	TroyCard: {
+		name:  "troy",
+		audit: "TROY",
+		ranges: []iinRange{
+			// Troy, 650052-650159 is allocated to it from Discover's 65
+			{4, 9792, 9792},
+			{6, 650052, 650159},
+		},
+		lengths:   []int{16},
+		gaps:      []int{4, 8, 12},
+		cvcName:   "CVV",
+		cvcLength: 3,
+		ui:        BrandUI{PrimaryColorHex: "#00A0DF", LogoSlug: "troy"},
+	},
+	VerveCard: {
+		name:  "verve",
+		audit: "VERVE",
+		ranges: []iinRange{
+			// Verve, which takes precedence over Maestro
+			{6, 506099, 506198},
+			{6, 507865, 507964},
+			{6, 650002, 650027},
+		},
+		lengths:   []int{16, 19},
+		gaps:      []int{4, 8, 12, 16},
+		cvcName:   "CVV",
+		cvcLength: 3,
+		ui:        BrandUI{PrimaryColorHex: "#00425F", LogoSlug: "verve"},
+	},
+	UATPCard: {
+		name:  "uatp",
+		audit: "UATP",
+		ranges: []iinRange{
+			{1, 1, 1},
+		},
+		lengths: []int{15},
+		gaps:    []int{4, 9},
+		ui:      BrandUI{PrimaryColorHex: "#1A3668", LogoSlug: "uatp"},
+	},
+	InstaPaymentCard: {
+		name:  "instapayment",
+		audit: "INSTAPAYMENT",
+		ranges: []iinRange{
+			// InstaPayment, which takes precedence over Maestro
+			{3, 637, 639},
+		},
+		lengths:   []int{16},
+		gaps:      []int{4, 8, 12},
+		cvcName:   "CVV",
+		cvcLength: 3,
+		ui:        BrandUI{PrimaryColorHex: "#0072BC", LogoSlug: "instapayment"},
+	},
+	VisaElectron: {
+		name:  "visa-electron",
+		audit: "ELECTRON",
+		ranges: []iinRange{
+			// Visa Electron, which takes precedence over VISA
+			{4, 4026, 4026},
+			{6, 417500, 417500},
+			{4, 4508, 4508},
+			{4, 4844, 4844},
+			{4, 4913, 4913},
+			{4, 4917, 4917},
+		},
+		lengths:   []int{16},
+		gaps:      []int{4, 8, 12},
+		cvcName:   "CVV",
+		cvcLength: 3,
+		ui:        BrandUI{PrimaryColorHex: "#1A1F71", LogoSlug: "visa-electron"},
+	},
+	CarteBancaire: {
+		name:      "cartes-bancaires",
+		audit:     "CB",
+		lengths:   []int{16},
+		gaps:      []int{4, 8, 12},
+		cvcName:   "CVV",
+		cvcLength: 3,
+		ui:        BrandUI{PrimaryColorHex: "#1B4F9C", LogoSlug: "cb"},
+	},
+	Forbrugsforeningen: {
+		name:  "forbrugsforeningen",
+		audit: "FORBRUGSFORENINGEN",
+		ranges: []iinRange{
+			// Forbrugsforeningen, which takes precedence over RuPay
+			{6, 600722, 600722},
+		},
+		lengths:   []int{16},
+		gaps:      []int{4, 8, 12},
+		cvcName:   "CVV",
+		cvcLength: 3,
+		ui:        BrandUI{PrimaryColorHex: "#003B6F", LogoSlug: "forbrugsforeningen"},
+	},
+	UzCard: {
+		name:  "uzcard",
+		audit: "UZCARD",
+		ranges: []iinRange{
+			{4, 8600, 8600},
+		},
+		lengths:   []int{16},
+		gaps:      []int{4, 8, 12},
+		cvcName:   "CVV",
+		cvcLength: 3,
+		ui:        BrandUI{PrimaryColorHex: "#1E3C93", LogoSlug: "uzcard"},
+	},
+	HumoCard: {
+		name:  "humo",
+		audit: "HUMO",
+		ranges: []iinRange{
+			{4, 9860, 9860},
+		},
+		lengths:   []int{16},
+		gaps:      []int{4, 8, 12},
+		cvcName:   "CVV",
+		cvcLength: 3,
+		ui:        BrandUI{PrimaryColorHex: "#00A651", LogoSlug: "humo"},
+	},
+	NapasCard: {
+		name:  "napas",
+		audit: "NAPAS",
+		ranges: []iinRange{
+			{4, 9704, 9704},
+		},
+		lengths:   []int{16, 17, 18, 19},
+		gaps:      []int{4, 8, 12, 16},
+		cvcName:   "CVV",
+		cvcLength: 3,
+		ui:        BrandUI{PrimaryColorHex: "#005BAA", LogoSlug: "napas"},
+	},
+	ChinaTUnion: {
+		name:  "t-union",
+		audit: "TUNION",
+		ranges: []iinRange{
+			{2, 31, 31},
+		},
+		lengths:   []int{19},
+		gaps:      []int{4, 8, 12, 16},
+		cvcName:   "CVN2",
+		cvcLength: 3,
+		ui:        BrandUI{PrimaryColorHex: "#E60012", LogoSlug: "t-union"},
+	},
+}
+
+// prefixRange maps PANs starting with lo-hi to typ
+type prefixRange struct {
+	iinRange
+	typ CardType
+}
+
+// IIN ranges of brands, ordered by card type
+//
+// If ranges overlap, the one with more digits takes precedence. Ranges with
+// same number of digits must not overlap, and digits must not exceed
+// maxPrefixDigits.
+var prefixRanges = brandRanges()
+
+func brandRanges() (ret []prefixRange) {
+	for _, t := range builtinTypes() {
+		for _, r := range brands[t].ranges {
+			ret = append(ret, prefixRange{iinRange: r, typ: t})
+		}
+	}
+	return
+}
+
+// builtinTypes returns card types in brands, ordered by their values
+func builtinTypes() (ret []CardType) {
+	ret = make([]CardType, 0, len(brands))
+	for t := range brands {
+		ret = append(ret, t)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return
+}
+
+// issuedLengths returns lengths of PAN issued by any brand in ascending order
+//
+// It panics if there's no layout for one of them, see panLayouts.
+func issuedLengths() (ret []int) {
+	seen := map[int]bool{}
+	for _, b := range brands {
+		for _, l := range b.lengths {
+			if panLayouts[l] == nil {
+				panic("creditcard: no layout for " + b.name + " pans of length " + strconv.Itoa(l))
+			}
+			if !seen[l] {
+				seen[l] = true
+				ret = append(ret, l)
+			}
+		}
+	}
+	sort.Ints(ret)
+	return
+}
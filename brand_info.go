@@ -0,0 +1,83 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+// ErrBrandUI is returned by SetBrandUI if the card type is unknown
+const ErrBrandUI ErrArgument = "cannot set ui metadata of unknown card type"
+
+// BrandUI is metadata to render badge of a brand
+type BrandUI struct {
+	// like "#1A1F71"
+	PrimaryColorHex string `json:"primary_color_hex"`
+	// icon name in common icon sets, like "visa" or "amex"
+	LogoSlug string `json:"logo_slug"`
+}
+
+// BrandInfo is metadata of a brand, see TypeInfo
+//
+// It can be marshaled to JSON and shipped to the browser directly.
+type BrandInfo struct {
+	Hints Hints   `json:"hints"`
+	UI    BrandUI `json:"ui"`
+}
+
+// WithBrandUI sets ui metadata of t, see SetBrandUI
+//
+// It panics if t is not known.
+func WithBrandUI(t CardType, ui BrandUI) (ret Option) {
+	return func(c *Config) {
+		if err := c.SetBrandUI(t, ui); err != nil {
+			panic("creditcard: cannot set ui metadata of " + t.String() + ": " + err.Error())
+		}
+	}
+}
+
+// SetBrandUI sets ui metadata of t, mainly for card types registered by
+// RegisterMatcher
+//
+// It overrides built-in metadata if t is a built-in card type. It returns
+// ErrBrandUI if t is not known, see CardType.Known.
+func SetBrandUI(t CardType, ui BrandUI) (err error) {
+	return defaultConfig.SetBrandUI(t, ui)
+}
+
+// SetBrandUI is same as package-level SetBrandUI, but applies to c only
+func (c *Config) SetBrandUI(t CardType, ui BrandUI) (err error) {
+	if !t.Known() {
+		return ErrBrandUI
+	}
+	c.uiLock.Lock()
+	defer c.uiLock.Unlock()
+
+	cur, _ := c.uis.Load().(map[CardType]BrandUI)
+	uis := make(map[CardType]BrandUI, len(cur)+1)
+	for k, v := range cur {
+		uis[k] = v
+	}
+	uis[t] = ui
+	c.uis.Store(uis)
+	return
+}
+
+// TypeInfo returns metadata of t
+//
+// Hints is same as FrontendHints(t). UI is empty if t is unknown or no
+// metadata is set for a registered card type.
+func TypeInfo(t CardType) (ret BrandInfo) {
+	return defaultConfig.TypeInfo(t)
+}
+
+// TypeInfo is same as package-level TypeInfo, but uses metadata set to c
+func (c *Config) TypeInfo(t CardType) (ret BrandInfo) {
+	ret.Hints = FrontendHints(t)
+
+	uis, _ := c.uis.Load().(map[CardType]BrandUI)
+	ui, ok := uis[t]
+	if !ok {
+		ui = brands[t].ui
+	}
+	ret.UI = ui
+	return
+}
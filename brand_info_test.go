@@ -0,0 +1,78 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestTypeInfo(t *testing.T) {
+	color := regexp.MustCompile(`^#[0-9A-F]{6}$`)
+	for _, typ := range AllCardTypes() {
+		ui := TypeInfo(typ).UI
+		if !color.MatchString(ui.PrimaryColorHex) || ui.LogoSlug == "" {
+			t.Fatalf("unexpected ui metadata of %d: %+v", typ, ui)
+		}
+	}
+
+	buf, err := json.Marshal(TypeInfo(AmericanExpress))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	expect := `{"hints":{"gaps":[4,10],"max_length":15,"cvc_name":"CID","cvc_length":4},"ui":{"primary_color_hex":"#006FCF","logo_slug":"amex"}}`
+	if actual := string(buf); actual != expect {
+		t.Log("expect:", expect)
+		t.Log("actual:", actual)
+		t.Fatal("unexpected result")
+	}
+
+	if ui := TypeInfo(UnknownCardType).UI; ui != (BrandUI{}) {
+		t.Fatal("unexpected ui metadata of unknown card type:", ui)
+	}
+}
+
+func TestSetBrandUI(t *testing.T) {
+	defer restoreMatchers()()
+	typ, err := RegisterMatcher("fleet", 0, func(prefix string) bool { return false })
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if ui := TypeInfo(typ).UI; ui != (BrandUI{}) {
+		t.Fatal("unexpected ui metadata:", ui)
+	}
+
+	ui := BrandUI{PrimaryColorHex: "#123456", LogoSlug: "fleet"}
+	if err = SetBrandUI(typ, ui); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	defer defaultConfig.uis.Store(map[CardType]BrandUI(nil))
+	actual := TypeInfo(typ)
+	if !reflect.DeepEqual(actual, BrandInfo{Hints: FrontendHints(typ), UI: ui}) {
+		t.Fatalf("unexpected result: %+v", actual)
+	}
+	if err = SetBrandUI(UnknownCardType, ui); err != ErrBrandUI {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// other Configs are not affected
+	visa := BrandUI{PrimaryColorHex: "#000000", LogoSlug: "black-visa"}
+	cfg := New(WithBrandUI(VISACard, visa))
+	if actual := New().TypeInfo(typ).UI; actual != (BrandUI{}) {
+		t.Fatal("unexpected ui metadata:", actual)
+	}
+	if actual := cfg.TypeInfo(VISACard).UI; actual != visa {
+		t.Fatal("unexpected ui metadata:", actual)
+	}
+	if actual := TypeInfo(VISACard).UI; actual != brands[VISACard].ui {
+		t.Fatal("unexpected ui metadata:", actual)
+	}
+
+	if err = SetBrandUI(UnknownCardType, ui); err != ErrBrandUI {
+		t.Fatal("unexpected error:", err)
+	}
+}
@@ -0,0 +1,121 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"container/list"
+	"crypto/hmac"
+	"crypto/sha256"
+	"strings"
+	"sync"
+)
+
+type cacheEntry struct {
+	key  [sha256.Size]byte
+	info Info
+}
+
+// Cache is a LRU cache of parsed Infos
+//
+// Entries are keyed by HMAC-SHA256 of the input, the input string is never
+// stored. Info is immutable, so cached instances can be shared. It is safe
+// for concurrent use.
+type Cache struct {
+	cfg     *Config
+	lock    sync.Mutex
+	size    int
+	key     []byte
+	entries map[[sha256.Size]byte]*list.Element
+	lru     *list.List // front is most recently used
+	hits    uint64
+	misses  uint64
+}
+
+// NewCache creates a Cache holding at most size Infos, size less than 1 is
+// treated as 1
+func NewCache(size int, key []byte) (ret *Cache) {
+	return defaultConfig.NewCache(size, key)
+}
+
+// NewCache is same as package-level NewCache, but Infos are parsed by c
+func (c *Config) NewCache(size int, key []byte) (ret *Cache) {
+	if size < 1 {
+		size = 1
+	}
+	return &Cache{
+		cfg:     c,
+		size:    size,
+		key:     key,
+		entries: make(map[[sha256.Size]byte]*list.Element, size),
+		lru:     list.New(),
+	}
+}
+
+// Parse returns cached Info of str, or parses it by Parse of the Config and
+// caches the result
+//
+// The result is always equal to a fresh parse. Inputs are cached as given,
+// so "4111-1111-1111-1111" and "4111111111111111" are different entries:
+// separators might change the result, like "4111-11-1111-1111" which is
+// padded to 16 digits. Errors are not cached.
+func (c *Cache) Parse(str string) (ret Info, err error) {
+	h := hmac.New(sha256.New, c.key)
+	h.Write([]byte(str))
+	var k [sha256.Size]byte
+	copy(k[:], h.Sum(nil))
+
+	c.lock.Lock()
+	if e, ok := c.entries[k]; ok {
+		c.hits++
+		c.lru.MoveToFront(e)
+		ret = e.Value.(*cacheEntry).info
+		c.lock.Unlock()
+		return
+	}
+	c.misses++
+	c.lock.Unlock()
+
+	// parse a copy, so the Info does not share memory with str
+	if ret, err = c.cfg.Parse(strings.Clone(str)); err != nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if e, ok := c.entries[k]; ok {
+		// parsed by another goroutine at the same time
+		c.lru.MoveToFront(e)
+		return e.Value.(*cacheEntry).info, nil
+	}
+	c.entries[k] = c.lru.PushFront(&cacheEntry{key: k, info: ret})
+	for c.lru.Len() > c.size {
+		e := c.lru.Back()
+		c.lru.Remove(e)
+		delete(c.entries, e.Value.(*cacheEntry).key)
+	}
+	return
+}
+
+// Len returns number of cached Infos
+func (c *Cache) Len() (ret int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.Len()
+}
+
+// Hits returns number of Parse calls served from cache
+func (c *Cache) Hits() (ret uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.hits
+}
+
+// Misses returns number of Parse calls not served from cache, including
+// failed ones
+func (c *Cache) Misses() (ret uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.misses
+}
@@ -0,0 +1,112 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	c := NewCache(2, []byte("key"))
+	a := "4111111111111111"
+	b := "5555-5555-5555-4444"
+	d := "3530111333300000"
+
+	parse := func(str string, hit bool) {
+		hits := c.Hits()
+		info, err := c.Parse(str)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if (c.Hits() > hits) != hit {
+			t.Fatalf("expect hit of %s: %v", str, hit)
+		}
+		fresh, _ := Parse(str)
+		if !reflect.DeepEqual(info, fresh) {
+			t.Log("expect:", fresh.Canonical())
+			t.Log("actual:", info.Canonical())
+			t.Fatal("cached info differs from fresh one")
+		}
+	}
+
+	parse(a, false)
+	parse(b, false)
+	parse(a, true)
+	parse(d, false) // evicts b
+	parse(a, true)
+	parse(b, false) // evicts d
+	parse(d, false)
+
+	if _, err := c.Parse("4111"); err == nil {
+		t.Fatal("expected error")
+	}
+	if c.Len() != 2 {
+		t.Fatal("unexpected length:", c.Len())
+	}
+	if c.Hits() != 2 || c.Misses() != 6 {
+		t.Fatal("unexpected counters:", c.Hits(), c.Misses())
+	}
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	c := NewCache(50, []byte("key"))
+	wg := &sync.WaitGroup{}
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for x := 0; x < 500; x++ {
+				pan := fmt.Sprintf("4%015d", (x*g)%100)
+				info, err := c.Parse(pan)
+				if err != nil || info.RawPAN() != pan {
+					t.Error("unexpected result:", pan, err)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if c.Len() != 50 {
+		t.Fatal("unexpected length:", c.Len())
+	}
+	if c.Hits()+c.Misses() != 8*500 {
+		t.Fatal("unexpected counters:", c.Hits(), c.Misses())
+	}
+}
+
+func TestCacheEqual(t *testing.T) {
+	cfg := New(WithSeparator(" "), WithVariableLength())
+	for _, x := range []struct {
+		cfg *Config
+		c   *Cache
+	}{
+		{defaultConfig, NewCache(10, []byte("key"))},
+		{cfg, cfg.NewCache(10, []byte("key"))},
+	} {
+		// same digits, different results
+		for _, str := range []string{
+			"4111-11-1111-1111",
+			"41111111111111",
+			"4111 11 1111 1111",
+			"4111-1111-1111",
+			"411111111111",
+			"4111 1111 1111",
+		} {
+			for round := 0; round < 2; round++ {
+				fresh, ferr := x.cfg.Parse(str)
+				info, err := x.c.Parse(str)
+				if (err == nil) != (ferr == nil) || !reflect.DeepEqual(info, fresh) {
+					t.Log("expect:", fresh, ferr)
+					t.Log("actual:", info, err)
+					t.Fatal("cached info differs from fresh one:", str)
+				}
+			}
+		}
+	}
+}
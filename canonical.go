@@ -0,0 +1,32 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import "strings"
+
+func (i *info) Canonical() (ret string) {
+	return i.RawPAN()
+}
+
+// Parse creates Info instance from raw (see FromRaw) or dashed (see
+// FromDashed) PAN
+//
+// Groups separated by the separator set by SetDefaultSeparator are accepted
+// too. It accepts every non-lossy form returned by Info, and is the
+// counterpart of Info.Canonical.
+func Parse(str string) (ret Info, err error) {
+	return defaultConfig.Parse(str)
+}
+
+// Parse is same as package-level Parse, but uses c
+func (c *Config) Parse(str string) (ret Info, err error) {
+	if strings.Contains(str, "-") {
+		return c.FromDashed(str)
+	}
+	if sep := c.Separator(); sep != "" && strings.Contains(str, sep) {
+		return c.FromSlice(strings.Split(str, sep))
+	}
+	return c.FromRaw(str)
+}
@@ -0,0 +1,68 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// randomInfo generates an Info with random digits, roughly 1/4 are masked
+func randomInfo(t *testing.T, r *rand.Rand, masked bool) (ret Info) {
+	buf := make([]byte, 16)
+	for idx := range buf {
+		buf[idx] = byte('0' + r.Intn(10))
+		if masked && r.Intn(4) == 0 {
+			buf[idx] = '*'
+		}
+	}
+	ret, err := FromRaw(string(buf))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	return
+}
+
+func TestCanonical(t *testing.T) {
+	info, _ := FromDashed("4111-1111-1111-1111")
+	if actual := info.Canonical(); actual != "4111111111111111" {
+		t.Log("expect:", "4111111111111111")
+		t.Log("actual:", actual)
+		t.Fatal("unexpected result")
+	}
+	masked, _ := FromMasked("411111", "1111")
+	if actual := masked.Canonical(); actual != "411111******1111" {
+		t.Log("expect:", "411111******1111")
+		t.Log("actual:", actual)
+		t.Fatal("unexpected result")
+	}
+}
+
+func TestCanonicalRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for x := 0; x < 10000; x++ {
+		info := randomInfo(t, r, x%2 == 1)
+		for _, str := range []string{info.Canonical(), info.RawPAN(), info.PAN()} {
+			actual, err := Parse(str)
+			if err != nil {
+				t.Fatalf("unexpected error parsing %s: %v", str, err)
+			}
+			if !reflect.DeepEqual(actual, info) {
+				t.Log("expect:", info.Canonical())
+				t.Log("actual:", actual.Canonical())
+				t.Fatal("round trip failed for", str)
+			}
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	for _, bad := range []string{"", "41111111111", "4111-1111-1111-1111-1", "4111 1111 1111 1111"} {
+		if _, err := Parse(bad); err == nil {
+			t.Fatal("expected error for", bad)
+		}
+	}
+}
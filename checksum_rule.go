@@ -0,0 +1,62 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+// ChecksumRule checks the check digit of pan, see SetValidationRule
+//
+// pan is always composed by digits, masked PANs are rejected before the rule
+// is called.
+type ChecksumRule func(pan string) (ok bool)
+
+// Predefined checksum rules
+var (
+	// LuhnChecksum is the default rule of every brand
+	LuhnChecksum ChecksumRule = checkLuhn
+	// SkipChecksum accepts every PAN, for brands issuing non-luhn cards
+	SkipChecksum ChecksumRule = func(pan string) (ok bool) { return true }
+)
+
+// WithValidationRule sets checksum rule of t, see SetValidationRule
+func WithValidationRule(t CardType, rule ChecksumRule) (ret Option) {
+	return func(c *Config) {
+		c.SetValidationRule(t, rule)
+	}
+}
+
+// SetValidationRule sets the checksum rule used by Info.Validate for PANs of
+// brand t, nil restores the default (LuhnChecksum)
+//
+// It is safe for concurrent use, but Infos being validated might use the
+// previous rule. The Luhn validator always uses luhn algorithm.
+func SetValidationRule(t CardType, rule ChecksumRule) {
+	defaultConfig.SetValidationRule(t, rule)
+}
+
+// SetValidationRule is same as package-level SetValidationRule, but applies
+// to c only
+func (c *Config) SetValidationRule(t CardType, rule ChecksumRule) {
+	c.ruleLock.Lock()
+	defer c.ruleLock.Unlock()
+
+	cur, _ := c.rules.Load().(map[CardType]ChecksumRule)
+	rules := make(map[CardType]ChecksumRule, len(cur)+1)
+	for k, v := range cur {
+		rules[k] = v
+	}
+	if rule == nil {
+		delete(rules, t)
+	} else {
+		rules[t] = rule
+	}
+	c.rules.Store(rules)
+}
+
+func (c *Config) checksumRule(t CardType) (ret ChecksumRule) {
+	rules, _ := c.rules.Load().(map[CardType]ChecksumRule)
+	if ret = rules[t]; ret == nil {
+		ret = LuhnChecksum
+	}
+	return
+}
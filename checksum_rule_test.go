@@ -0,0 +1,76 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import "testing"
+
+func TestValidationRule(t *testing.T) {
+	const (
+		nonLuhn     = "6212345678901234" // UnionPay
+		invalidVISA = "4111111111000067"
+		validVISA   = "4111111111000066"
+	)
+	validate := func(c *Config, pan string) (err error) {
+		info, err := c.Parse(pan)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		return info.Validate()
+	}
+
+	strict := New()
+	relaxed := New(WithValidationRule(UnionPay, SkipChecksum))
+	cases := []struct {
+		name   string
+		c      *Config
+		pan    string
+		expect error
+	}{
+		{"strict-unionpay", strict, nonLuhn, ErrValidate},
+		{"relaxed-unionpay", relaxed, nonLuhn, nil},
+		{"relaxed-masked", relaxed, "621234******1234", ErrValidateMasked},
+		{"strict-visa", strict, invalidVISA, ErrValidate},
+		{"relaxed-visa", relaxed, invalidVISA, ErrValidate},
+		{"relaxed-valid-visa", relaxed, validVISA, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := validate(c.c, c.pan); actual != c.expect {
+				t.Log("expect:", c.expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+		})
+	}
+
+	// alternative algorithm
+	relaxed.SetValidationRule(UnionPay, func(pan string) bool { return pan[len(pan)-1] == '4' })
+	if err := validate(relaxed, nonLuhn); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := validate(relaxed, "6212345678901235"); err != ErrValidate {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// Luhn validator and other configs are not affected
+	info, _ := relaxed.Parse(nonLuhn)
+	if err := Luhn.Validate(info); err != ErrValidate {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := validate(defaultConfig, nonLuhn); err != ErrValidate {
+		t.Fatal("unexpected error:", err)
+	}
+
+	relaxed.SetValidationRule(UnionPay, nil)
+	if err := validate(relaxed, nonLuhn); err != ErrValidate {
+		t.Fatal("unexpected error after restoring default:", err)
+	}
+
+	SetValidationRule(UnionPay, SkipChecksum)
+	defer SetValidationRule(UnionPay, nil)
+	if err := validate(defaultConfig, nonLuhn); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+}
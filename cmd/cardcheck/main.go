@@ -0,0 +1,126 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+// Command cardcheck checks PANs from the terminal
+//
+// PANs are read from arguments, or from stdin (one per line) if there's no
+// argument. For each PAN, brand, masked form, length and validation result
+// are printed. Raw PANs are never printed.
+//
+// Usage:
+//
+//	cardcheck [-json | -mask] [pan ...]
+//
+// With -json, each result is printed as a JSON object in its own line. With
+// -mask, only the masked form is printed, which is handy for piping.
+//
+// Exit code is 1 if any PAN is invalid (including masked ones, which cannot be
+// validated), or 2 if arguments are wrong.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/raohwork/creditcard"
+)
+
+type result struct {
+	Brand  string `json:"brand,omitempty"`
+	Masked string `json:"masked,omitempty"`
+	Length int    `json:"length,omitempty"`
+	Valid  bool   `json:"valid"`
+	Error  string `json:"error,omitempty"`
+}
+
+func check(line string) (ret result) {
+	// spaces are common in copy-pasted PANs
+	info, err := creditcard.Parse(strings.Join(strings.Fields(line), ""))
+	if err != nil {
+		ret.Error = err.Error()
+		return
+	}
+
+	// brand|first6|last4|length|status
+	f := strings.Split(info.AuditString(), "|")
+	ret.Brand = f[0]
+	ret.Masked = info.RawMasked()
+	ret.Length, _ = strconv.Atoi(f[3])
+	if err = info.Validate(); err != nil {
+		ret.Error = err.Error()
+		return
+	}
+	ret.Valid = true
+	return
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) (ret int) {
+	fs := flag.NewFlagSet("cardcheck", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	asJSON := fs.Bool("json", false, "print results in JSON lines")
+	maskOnly := fs.Bool("mask", false, "print masked form only")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *asJSON && *maskOnly {
+		fmt.Fprintln(stderr, "cardcheck: -json and -mask cannot be used together")
+		return 2
+	}
+
+	out := bufio.NewWriter(stdout)
+	defer out.Flush()
+	enc := json.NewEncoder(out)
+	handle := func(line string) {
+		r := check(line)
+		if !r.Valid {
+			ret = 1
+		}
+		switch {
+		case *asJSON:
+			enc.Encode(r)
+		case *maskOnly:
+			if r.Masked == "" {
+				fmt.Fprintln(stderr, "cardcheck:", r.Error)
+			}
+			fmt.Fprintln(out, r.Masked)
+		case r.Masked == "":
+			fmt.Fprintln(out, "error:", r.Error)
+		default:
+			status := "valid"
+			if !r.Valid {
+				status = "invalid: " + r.Error
+			}
+			fmt.Fprintf(out, "%s\t%s\t%d\t%s\n", r.Brand, r.Masked, r.Length, status)
+		}
+	}
+
+	if fs.NArg() > 0 {
+		for _, arg := range fs.Args() {
+			handle(arg)
+		}
+		return
+	}
+
+	s := bufio.NewScanner(stdin)
+	for s.Scan() {
+		if line := strings.TrimSpace(s.Text()); line != "" {
+			handle(line)
+		}
+	}
+	if err := s.Err(); err != nil {
+		fmt.Fprintln(stderr, "cardcheck:", err)
+		return 2
+	}
+	return
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
@@ -0,0 +1,76 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	cases := []struct {
+		name   string
+		args   []string
+		stdin  string
+		expect string
+		code   int
+	}{
+		{
+			name:   "args",
+			args:   []string{"4111111111000066", "4111 1111 1100 0066"},
+			expect: "VISA\t411111******0066\t16\tvalid\nVISA\t411111******0066\t16\tvalid\n",
+		},
+		{
+			name: "invalid",
+			args: []string{"4111111111000067", "bad"},
+			code: 1,
+			expect: "VISA\t411111******0067\t16\tinvalid: creditcard: incorrect pan format: invalid pan\n" +
+				"error: creditcard: incorrect pan format: raw pan must be digits or asterisks of supported length: got 3, 3-digit pans are not issued by supported brands, check for missing or extra digits\n",
+		},
+		{
+			name:   "stdin",
+			stdin:  "4111111111000066\n\n  4111-1111-1100-0066  \n",
+			expect: "VISA\t411111******0066\t16\tvalid\nVISA\t411111******0066\t16\tvalid\n",
+		},
+		{
+			name:  "json",
+			args:  []string{"-json"},
+			stdin: "4111111111000066\n411111******0066\nbad\n",
+			code:  1,
+			expect: `{"brand":"VISA","masked":"411111******0066","length":16,"valid":true}` + "\n" +
+				`{"brand":"VISA","masked":"411111******0066","length":16,"valid":false,"error":"creditcard: incorrect pan format: masked pan cannot be validated"}` + "\n" +
+				`{"valid":false,"error":"creditcard: incorrect pan format: raw pan must be digits or asterisks of supported length: got 3, 3-digit pans are not issued by supported brands, check for missing or extra digits"}` + "\n",
+		},
+		{
+			name:   "mask",
+			args:   []string{"-mask", "4111111111000066", "4111111111000067"},
+			code:   1,
+			expect: "411111******0066\n411111******0067\n",
+		},
+		{
+			name: "usage",
+			args: []string{"-json", "-mask"},
+			code: 2,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			code := run(c.args, strings.NewReader(c.stdin), stdout, stderr)
+			if code != c.code {
+				t.Log("expect:", c.code)
+				t.Log("actual:", code, stderr.String())
+				t.Fatal("unexpected exit code")
+			}
+			if actual := stdout.String(); actual != c.expect {
+				t.Log("expect:", c.expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected output")
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import "strings"
+
+// BIN prefix of Visa/Dankort co-badged cards
+const dankortCoBadgePrefix = "4571"
+
+// coBadge overrides card type of PANs starting with prefix
+type coBadge struct {
+	prefix string
+	typ    CardType
+}
+
+// WithDankortCoBadge detects Visa/Dankort co-badged cards (4571) as Dankort
+// instead of VISA
+//
+// Such cards can be processed by both schemes, Danish merchants usually route
+// them through Dankort.
+func WithDankortCoBadge() (ret Option) {
+	return func(c *Config) {
+		c.coBadges = append(c.coBadges, coBadge{prefix: dankortCoBadgePrefix, typ: DankortCard})
+	}
+}
+
+// WithCarteBancaire detects PANs starting with any of prefixes as
+// CarteBancaire instead of their international brand (VISA or MasterCard)
+//
+// Cartes Bancaires ranges are not public, they must be provided by your
+// acquirer. Config without this option still detects the international brand,
+// so both brands can be told by using two Configs.
+func WithCarteBancaire(prefixes ...string) (ret Option) {
+	return func(c *Config) {
+		for _, p := range prefixes {
+			c.coBadges = append(c.coBadges, coBadge{prefix: p, typ: CarteBancaire})
+		}
+	}
+}
+
+// cardType detects card type of pan, respecting co-badges and matchers of c
+func (c *Config) cardType(pan string) (ret CardType) {
+	for _, b := range c.coBadges {
+		if b.prefix != "" && strings.HasPrefix(pan, b.prefix) {
+			return b.typ
+		}
+	}
+	return c.matchedCardType(pan)
+}
@@ -0,0 +1,89 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import "testing"
+
+func TestDankort(t *testing.T) {
+	cobadge := New(WithDankortCoBadge())
+	cases := []struct {
+		pan     string
+		expect  CardType
+		cobadge CardType
+	}{
+		{"5018000000000000", MaestroCard, MaestroCard},
+		{"5019717010103742", DankortCard, DankortCard},
+		{"5020000000000000", MaestroCard, MaestroCard},
+		{"4571000000000000", VISACard, DankortCard},
+		{"4570000000000000", VISACard, VISACard},
+		{"4572000000000000", VISACard, VISACard},
+	}
+	for _, c := range cases {
+		info, err := FromRaw(c.pan)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if actual := info.CardType(); actual != c.expect {
+			t.Log("expect:", c.expect)
+			t.Log("actual:", actual)
+			t.Fatal("unexpected card type of", c.pan)
+		}
+
+		if info, err = cobadge.FromMasked(c.pan[:6], c.pan[12:]); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if actual := info.CardType(); actual != c.cobadge {
+			t.Log("expect:", c.cobadge)
+			t.Log("actual:", actual)
+			t.Fatal("unexpected card type of", c.pan, "with co-badge option")
+		}
+	}
+
+	// card type is detected again with same config
+	info, _ := cobadge.FromRaw("4111111111111111")
+	if info, _ = info.WithSection(0, "4571"); info.CardType() != DankortCard {
+		t.Fatal("unexpected card type:", info.CardType())
+	}
+}
+
+func TestCarteBancaire(t *testing.T) {
+	cb := New(WithCarteBancaire("4970", "513283"))
+	cases := []struct {
+		pan    string
+		expect CardType
+		cb     CardType
+	}{
+		{"4970101122334455", VISACard, CarteBancaire},
+		{"5132830000000000", MasterCard, CarteBancaire},
+		{"5132840000000000", MasterCard, MasterCard},
+		{"4111111111111111", VISACard, VISACard},
+	}
+	for _, c := range cases {
+		info, err := FromRaw(c.pan)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if actual := info.CardType(); actual != c.expect {
+			t.Log("expect:", c.expect)
+			t.Log("actual:", actual)
+			t.Fatal("unexpected card type of", c.pan)
+		}
+
+		if info, err = cb.Parse(c.pan); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if actual := info.CardType(); actual != c.cb {
+			t.Log("expect:", c.cb)
+			t.Log("actual:", actual)
+			t.Fatal("unexpected card type of", c.pan, "with cb ranges")
+		}
+	}
+
+	// masked digits never match
+	info, _ := cb.FromMasked("497***", "4455")
+	if info.CardType() != VISACard {
+		t.Fatal("unexpected card type:", info.CardType())
+	}
+}
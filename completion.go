@@ -0,0 +1,56 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ErrCompletionLimit is returned by EnumerateCompletions if there're too many
+// candidates to enumerate
+type ErrCompletionLimit struct {
+	Masked int // number of masked digits
+	Limit  int // the limit passed to EnumerateCompletions
+}
+
+func (e ErrCompletionLimit) Error() (ret string) {
+	return "creditcard: invalid argument: " + strconv.Itoa(e.Masked) +
+		" masked digits exceed the limit of " + strconv.Itoa(e.Limit) +
+		" candidates"
+}
+
+func (i *info) EnumerateCompletions(limit int) (ret []Info, err error) {
+	if i.IsZero() {
+		return nil, ErrNoCard
+	}
+
+	buf := []byte(i.RawPAN())
+	pos := make([]int, 0, len(buf))
+	space := 1
+	for idx, c := range buf {
+		if c != '*' {
+			continue
+		}
+		pos = append(pos, idx)
+		if space *= 10; space > limit {
+			return nil, ErrCompletionLimit{Masked: strings.Count(string(buf), "*"), Limit: limit}
+		}
+	}
+
+	for n := 0; n < space; n++ {
+		v := n
+		for x := len(pos) - 1; x >= 0; x-- {
+			buf[pos[x]] = byte('0' + v%10)
+			v /= 10
+		}
+		if !luhnValid(buf) {
+			continue
+		}
+		info, _ := i.config().fromRaw(string(buf))
+		ret = append(ret, info)
+	}
+	return
+}
@@ -0,0 +1,110 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEnumerateCompletions(t *testing.T) {
+	cases := []struct {
+		pan    string
+		expect []string
+	}{
+		{"411111111111111*", []string{"4111111111111111"}},
+		{"4111111*11111111", []string{"4111111111111111"}},
+		{"4111111111111112", nil},
+		{"4111111111111111", []string{"4111111111111111"}},
+		{"41111111111111**", []string{
+			"4111111111111103", "4111111111111111", "4111111111111129",
+			"4111111111111137", "4111111111111145", "4111111111111152",
+			"4111111111111160", "4111111111111178", "4111111111111186",
+			"4111111111111194",
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.pan, func(t *testing.T) {
+			info, _ := FromRaw(c.pan)
+			infos, err := info.EnumerateCompletions(100)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			var actual []string
+			for _, x := range infos {
+				actual = append(actual, x.RawPAN())
+			}
+			if !reflect.DeepEqual(actual, c.expect) {
+				t.Log("expect:", c.expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+		})
+	}
+}
+
+func TestEnumerateCompletionsComplete(t *testing.T) {
+	info, _ := FromRaw("4111111*11*11111")
+	infos, err := info.EnumerateCompletions(100)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// exactly one completion for each value of one masked digit
+	if len(infos) != 10 {
+		t.Fatal("unexpected number of completions:", len(infos))
+	}
+	seen := map[byte]bool{}
+	for _, x := range infos {
+		pan := x.RawPAN()
+		if !luhnValid([]byte(pan)) {
+			t.Fatal("completion is not luhn-valid:", pan)
+		}
+		seen[pan[7]] = true
+	}
+	if len(seen) != 10 {
+		t.Fatal("missing completions")
+	}
+}
+
+func TestEnumerateCompletionsLimit(t *testing.T) {
+	info, _ := FromMasked("411111", "1111")
+	_, err := info.EnumerateCompletions(999999)
+	expect := ErrCompletionLimit{Masked: 6, Limit: 999999}
+	if err != expect {
+		t.Log("expect:", expect)
+		t.Log("actual:", err)
+		t.Fatal("unexpected error")
+	}
+
+	info, _ = FromRaw("41111111111111**")
+	if _, err = info.EnumerateCompletions(99); err != (ErrCompletionLimit{Masked: 2, Limit: 99}) {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestEnumerateCompletionsPrint(t *testing.T) {
+	info, _ := FromRaw("41111111111111**")
+	infos, err := info.EnumerateCompletions(100)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	for _, verb := range []string{"%v", "%+v", "%#v", "%s"} {
+		actual := fmt.Sprintf(verb, infos)
+		for _, x := range infos {
+			if strings.Contains(actual, x.RawPAN()) {
+				t.Fatalf("%s leaks %s: %s", verb, x.RawPAN(), actual)
+			}
+		}
+		if !strings.Contains(actual, "4111-11**-****-1103") {
+			t.Log("actual:", actual)
+			t.Fatalf("%s does not print masked pan", verb)
+		}
+	}
+}
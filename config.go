@@ -0,0 +1,108 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Option configures a Config
+type Option func(c *Config)
+
+// WithSeparator sets the group separator, see SetDefaultSeparator
+func WithSeparator(s string) (ret Option) {
+	return func(c *Config) {
+		c.SetSeparator(s)
+	}
+}
+
+// WithObserver sets the Observer, see SetObserver
+func WithObserver(o Observer) (ret Option) {
+	return func(c *Config) {
+		c.SetObserver(o)
+	}
+}
+
+// WithNoPadding makes FromSlice (and FromDashed, FromPart) return
+// ErrSectionValue for sections shorter than 4 characters or missing, instead
+// of padding them with asterisks
+func WithNoPadding() (ret Option) {
+	return func(c *Config) {
+		c.noPadding = true
+	}
+}
+
+// WithVariableLength makes FromSlice (and FromDashed, FromPart) accept
+// groups of 12- to 19-digit PANs, see FromSlice
+//
+// By default, only groups of 14-, 15- and 16-digit PANs are accepted, and
+// shorter sections are padded to 16 digits. FromRaw always accepts 12 to 19
+// digits.
+func WithVariableLength() (ret Option) {
+	return func(c *Config) {
+		c.variableLength = true
+	}
+}
+
+// Config bundles settings of this package, so libraries in same process can
+// use different settings without interfering each other
+//
+// Methods of Config mirror package-level functions, and Infos created by a
+// Config keep using it. Package-level functions use a default Config, which
+// can be changed by package-level setters like SetDefaultSeparator. It is
+// safe for concurrent use.
+type Config struct {
+	separator atomic.Value // string
+	observer  atomic.Value // observerBox
+
+	ruleLock sync.Mutex   // serializes SetValidationRule
+	rules    atomic.Value // map[CardType]ChecksumRule
+
+	matcherLock sync.Mutex   // serializes RegisterMatcher
+	matchers    atomic.Value // []matcher, sorted by priority
+
+	uiLock sync.Mutex   // serializes SetBrandUI
+	uis    atomic.Value // map[CardType]BrandUI
+
+	unicodeDigits  bool      // see WithUnicodeDigits
+	ocrCorrections bool      // see WithOCRCorrections
+	noPadding      bool      // see WithNoPadding
+	variableLength bool      // see WithVariableLength
+	coBadges       []coBadge // see WithDankortCoBadge and WithCarteBancaire
+}
+
+var defaultConfig = New()
+
+// New creates a Config with default settings, which is then modified by opts
+func New(opts ...Option) (ret *Config) {
+	ret = &Config{}
+	ret.separator.Store("-")
+	ret.observer.Store(observerBox{})
+	ret.matchers.Store([]matcher(nil))
+	for _, o := range opts {
+		o(ret)
+	}
+	return
+}
+
+// SetSeparator is same as SetDefaultSeparator, but applies to c only
+func (c *Config) SetSeparator(s string) {
+	c.separator.Store(s)
+}
+
+// Separator returns the group separator
+func (c *Config) Separator() (ret string) {
+	return c.separator.Load().(string)
+}
+
+// SetObserver is same as package-level SetObserver, but applies to c only
+func (c *Config) SetObserver(o Observer) {
+	c.observer.Store(observerBox{o: o})
+}
+
+func (c *Config) currentObserver() (ret Observer) {
+	return c.observer.Load().(observerBox).o
+}
@@ -0,0 +1,55 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import "testing"
+
+func TestConfig(t *testing.T) {
+	recA, recB := &recordingObserver{}, &recordingObserver{}
+	a := New(WithSeparator(" "), WithObserver(recA))
+	b := New(WithSeparator(""), WithObserver(recB))
+
+	infoA, _ := a.FromRaw("4111111111111111")
+	infoB, _ := b.Parse("4111111111111111")
+	infoD, _ := FromRaw("4111111111111111")
+	cases := map[string][2]string{
+		"a":       {infoA.PAN(), "4111 1111 1111 1111"},
+		"b":       {infoB.PAN(), "4111111111111111"},
+		"default": {infoD.PAN(), "4111-1111-1111-1111"},
+		"masked":  {infoA.Masked(), "4111 11** **** 1111"},
+	}
+	for name, c := range cases {
+		if c[0] != c[1] {
+			t.Log("expect:", c[1])
+			t.Log("actual:", c[0])
+			t.Fatal("unexpected result of", name)
+		}
+	}
+
+	if len(recA.events) != 1 || len(recB.events) != 1 {
+		t.Fatal("unexpected events:", recA.events, recB.events)
+	}
+	infoA.Validate()
+	if len(recA.events) != 2 || len(recB.events) != 1 {
+		t.Fatal("unexpected events:", recA.events, recB.events)
+	}
+
+	// Infos keep using their config
+	a.SetSeparator("/")
+	if actual := infoA.PAN(); actual != "4111/1111/1111/1111" {
+		t.Fatal("unexpected result:", actual)
+	}
+	x, err := a.Parse("4111/1111/1111/1111")
+	if err != nil || x.RawPAN() != "4111111111111111" {
+		t.Fatal("unexpected result:", err)
+	}
+	if _, err = b.Parse("4111/1111/1111/1111"); err == nil {
+		t.Fatal("config b should not accept separator of config a")
+	}
+	anon, _ := infoA.Anonymize([]byte("key"))
+	if actual := anon.PAN(); actual[4] != '/' {
+		t.Fatal("derived info does not use same config:", actual)
+	}
+}
@@ -0,0 +1,132 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+// Package creditcardtest provides fixtures and helpers for testing code
+// depending on package creditcard
+//
+// It must be imported by tests only.
+package creditcardtest
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"testing"
+
+	"github.com/raohwork/creditcard"
+)
+
+func mustParse(pan string) (ret creditcard.Info) {
+	ret, err := creditcard.Parse(pan)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+// Standard test numbers published by card brands or payment gateways, they
+// pass Validate
+var (
+	VISA       = mustParse("4111111111111111")
+	MasterCard = mustParse("5105105105105100")
+	JCB        = mustParse("3530111333300000")
+	UnionPay   = mustParse("6200000000000005")
+	AMEX       = mustParse("378282246310005")
+	DinersClub = mustParse("30569309025904")
+	Maestro    = mustParse("6759649826438453")
+)
+
+// Infos returns fixtures above
+func Infos() (ret []creditcard.Info) {
+	return []creditcard.Info{VISA, MasterCard, JCB, UnionPay, AMEX, DinersClub, Maestro}
+}
+
+// AssertMasked fails t if s contains an unmasked PAN, see
+// creditcard.ContainsPAN
+func AssertMasked(t testing.TB, s string) {
+	t.Helper()
+	if creditcard.ContainsPAN([]byte(s)) {
+		t.Errorf("unmasked pan found in %q", creditcard.RedactString(s))
+	}
+}
+
+// leading digits used by Generator
+var brandPrefixes = map[creditcard.CardType][]string{
+	creditcard.VISACard:           {"4"},
+	creditcard.MasterCard:         {"51", "52", "53", "54", "55"},
+	creditcard.JCBCard:            {"3528", "3530", "3589"},
+	creditcard.UnionPay:           {"62"},
+	creditcard.AmericanExpress:    {"34", "37"},
+	creditcard.DinersClub:         {"300", "305", "36", "38"},
+	creditcard.MaestroCard:        {"56", "57", "58", "67"},
+	creditcard.MirCard:            {"2200", "2204"},
+	creditcard.RuPayCard:          {"508", "6070", "82"},
+	creditcard.EloCard:            {"509000", "636368", "650405"},
+	creditcard.HipercardCard:      {"606282", "3841"},
+	creditcard.DankortCard:        {"5019"},
+	creditcard.TroyCard:           {"9792", "650100"},
+	creditcard.VerveCard:          {"506099", "507900", "650010"},
+	creditcard.UATPCard:           {"1"},
+	creditcard.InstaPaymentCard:   {"637", "638", "639"},
+	creditcard.VisaElectron:       {"4026", "417500", "4913"},
+	creditcard.Forbrugsforeningen: {"600722"},
+	creditcard.UzCard:             {"8600"},
+	creditcard.HumoCard:           {"9860"},
+	creditcard.NapasCard:          {"9704"},
+	creditcard.ChinaTUnion:        {"31"},
+}
+
+// length of PANs created by Generator, 16 if not listed
+var brandLengths = map[creditcard.CardType]int{
+	creditcard.AmericanExpress: 15,
+	creditcard.DinersClub:      14,
+	creditcard.UATPCard:        15,
+	creditcard.ChinaTUnion:     19,
+}
+
+// Generator creates random but deterministic PANs, see NewGenerator
+type Generator struct {
+	t testing.TB
+	r *rand.Rand
+}
+
+// NewGenerator creates a Generator seeded by name of t, so a test gets same
+// PANs every run, and different tests get different PANs
+func NewGenerator(t testing.TB) (ret *Generator) {
+	h := fnv.New64a()
+	h.Write([]byte(t.Name()))
+	return &Generator{t: t, r: rand.New(rand.NewSource(int64(h.Sum64())))}
+}
+
+// Info creates a PAN of brand typ which passes Validate, it is 15 digits for
+// AMEX and UATP, 14 digits for Diners Club, 19 digits for China T-Union and
+// 16 digits for others
+//
+// It fails t if typ is not supported.
+func (g *Generator) Info(typ creditcard.CardType) (ret creditcard.Info) {
+	g.t.Helper()
+	prefixes, ok := brandPrefixes[typ]
+	if !ok {
+		g.t.Fatalf("creditcardtest: cannot generate pan of card type %d", typ)
+		return
+	}
+
+	l, ok := brandLengths[typ]
+	if !ok {
+		l = 16
+	}
+	buf := []byte(prefixes[g.r.Intn(len(prefixes))])
+	for len(buf) < l-1 {
+		buf = append(buf, byte('0'+g.r.Intn(10)))
+	}
+	buf = append(buf, '0')
+	for d := byte('0'); d <= '9'; d++ {
+		buf[l-1] = d
+		info, err := creditcard.FromRaw(string(buf))
+		if err == nil && info.Validate() == nil {
+			return info
+		}
+	}
+	g.t.Fatalf("creditcardtest: no check digit found for %s", buf[:l-1])
+	return
+}
@@ -0,0 +1,104 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcardtest
+
+import (
+	"testing"
+
+	"github.com/raohwork/creditcard"
+)
+
+func TestInfos(t *testing.T) {
+	expect := []creditcard.CardType{
+		creditcard.VISACard,
+		creditcard.MasterCard,
+		creditcard.JCBCard,
+		creditcard.UnionPay,
+		creditcard.AmericanExpress,
+		creditcard.DinersClub,
+		creditcard.MaestroCard,
+	}
+	for idx, info := range Infos() {
+		if actual := info.CardType(); actual != expect[idx] {
+			t.Log("expect:", expect[idx])
+			t.Log("actual:", actual)
+			t.Fatal("unexpected card type of", info.Masked())
+		}
+		if err := info.Validate(); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+}
+
+// fakeT records failures instead of failing the test
+type fakeT struct {
+	testing.TB
+	name   string
+	failed bool
+	fatal  bool
+}
+
+func (t *fakeT) Helper()                                   {}
+func (t *fakeT) Name() string                              { return t.name }
+func (t *fakeT) Errorf(format string, args ...interface{}) { t.failed = true }
+func (t *fakeT) Fatalf(format string, args ...interface{}) { t.failed, t.fatal = true, true }
+
+func TestAssertMasked(t *testing.T) {
+	cases := []struct {
+		s      string
+		failed bool
+	}{
+		{"card 411111******1111 charged", false},
+		{"order 1234567890", false},
+		{"card 4111 1111 1111 1111 charged", true},
+		{`{"pan":"5105105105105100"}`, true},
+	}
+	for _, c := range cases {
+		ft := &fakeT{}
+		AssertMasked(ft, c.s)
+		if ft.failed != c.failed {
+			t.Fatalf("unexpected result of %q: %v", c.s, ft.failed)
+		}
+	}
+}
+
+func TestGenerator(t *testing.T) {
+	g := NewGenerator(t)
+	seen := map[string]bool{}
+	for _, typ := range creditcard.AllCardTypes() {
+		if typ == creditcard.CarteBancaire {
+			// detected only with creditcard.WithCarteBancaire
+			continue
+		}
+		for i := 0; i < 20; i++ {
+			info := g.Info(typ)
+			if info.CardType() != typ || len(info.RawPAN()) != brandLengths[typ] && len(info.RawPAN()) != 16 {
+				t.Fatal("unexpected card type of", info.Masked())
+			}
+			if err := info.Validate(); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			AssertMasked(t, info.Masked())
+			seen[info.RawPAN()] = true
+		}
+	}
+	if len(seen) < (len(creditcard.AllCardTypes())-1)*18 {
+		t.Fatal("too many duplicated pans:", len(seen))
+	}
+
+	// deterministic per test name
+	a := NewGenerator(&fakeT{name: "a"}).Info(creditcard.VISACard)
+	b := NewGenerator(&fakeT{name: "a"}).Info(creditcard.VISACard)
+	c := NewGenerator(&fakeT{name: "b"}).Info(creditcard.VISACard)
+	if a.RawPAN() != b.RawPAN() || a.RawPAN() == c.RawPAN() {
+		t.Fatal("unexpected result:", a.Masked(), b.Masked(), c.Masked())
+	}
+
+	ft := &fakeT{name: "unknown"}
+	NewGenerator(ft).Info(creditcard.UnknownCardType)
+	if !ft.fatal {
+		t.Fatal("unsupported card type should fail the test")
+	}
+}
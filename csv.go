@@ -0,0 +1,168 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+)
+
+// ErrCSVColumn is returned by ValidateCSV if the pan column is not found in
+// csv header
+const ErrCSVColumn ErrArgument = "pan column not found in csv header"
+
+// Header of columns appended by ValidateCSV
+const (
+	CSVColumnBrand  = "brand"
+	CSVColumnMasked = "masked_pan"
+	CSVColumnResult = "result"
+	CSVColumnError  = "error"
+)
+
+// Values of CSVColumnResult
+const (
+	CSVResultValid     = "valid"     // passed Validate
+	CSVResultMasked    = "masked"    // masked pan cannot be validated
+	CSVResultInvalid   = "invalid"   // failed Validate
+	CSVResultMalformed = "malformed" // failed to parse
+)
+
+// Values of CSVColumnError, stable codes of errors returned by Parse
+const (
+	CSVErrorUnsupportedLength = "unsupported_length" // ErrUnsupportedLength
+	CSVErrorRaw               = "raw"                // ErrRaw, other than ErrUnsupportedLength
+	CSVErrorSection           = "section"            // ErrSection and ErrSectionValue
+	CSVErrorMasked            = "masked"             // ErrMasked
+	CSVErrorOther             = "other"              // other errors
+)
+
+// CSVOption configures ValidateCSV
+type CSVOption func(c *csvConfig)
+
+type csvConfig struct {
+	mask bool
+}
+
+// CSVMaskPAN replaces values in the pan column by masked form
+//
+// Values failed to parse are masked by RedactString instead.
+func CSVMaskPAN() (ret CSVOption) {
+	return func(c *csvConfig) {
+		c.mask = true
+	}
+}
+
+func csvResult(err error) (ret string) {
+	switch {
+	case err == nil:
+		return CSVResultValid
+	case errors.Is(err, ErrValidateMasked):
+		return CSVResultMasked
+	}
+	return CSVResultInvalid
+}
+
+func csvError(err error) (ret string) {
+	var l *ErrUnsupportedLength
+	switch {
+	case errors.As(err, &l):
+		return CSVErrorUnsupportedLength
+	case errors.Is(err, ErrRaw):
+		return CSVErrorRaw
+	case errors.Is(err, ErrSection):
+		return CSVErrorSection
+	case errors.Is(err, ErrMasked):
+		return CSVErrorMasked
+	}
+	return CSVErrorOther
+}
+
+// ValidateCSV reads csv from r, and writes it to w with columns brand, masked
+// pan, validation result and parse error appended
+//
+// The first row is header, panColumn is the name of the column containing
+// PAN, which is parsed by Parse. Every row is written, values of result column
+// are CSVResultValid, CSVResultMasked, CSVResultInvalid or CSVResultMalformed.
+// Brand names are same as AuditString, and are empty along with masked pan if
+// the PAN failed to parse. The error column is empty unless the result is
+// CSVResultMalformed, in which case it is one of CSVError* codes. Rows are processed one by one, so it can handle
+// large files.
+//
+// ret is statistics of processed rows, see SummarizeStrings. err is
+// ErrCSVColumn if panColumn is not found, or errors from reading and writing
+// csv.
+func ValidateCSV(r io.Reader, w io.Writer, panColumn string, opts ...CSVOption) (ret Summary, err error) {
+	cfg := csvConfig{}
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	cw := csv.NewWriter(w)
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return ret, ErrCSVColumn
+	}
+	if err != nil {
+		return
+	}
+	col := -1
+	for idx, name := range header {
+		if strings.TrimSpace(name) == panColumn {
+			col = idx
+			break
+		}
+	}
+	if col < 0 {
+		return ret, ErrCSVColumn
+	}
+	header = append(header, CSVColumnBrand, CSVColumnMasked, CSVColumnResult, CSVColumnError)
+	if err = cw.Write(header); err != nil {
+		return
+	}
+
+	for {
+		var row []string
+		row, err = cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return
+		}
+
+		var brand, masked, result, code string
+		info, perr := Parse(strings.TrimSpace(row[col]))
+		if perr != nil {
+			ret.addError(perr)
+			result = CSVResultMalformed
+			code = csvError(perr)
+			if cfg.mask {
+				row[col] = RedactString(row[col])
+			}
+		} else {
+			ret.Add(info)
+			brand = auditBrand(info.CardType())
+			masked = info.RawMasked()
+			result = csvResult(info.Validate())
+			if cfg.mask {
+				row[col] = masked
+			}
+		}
+
+		row = append(row, brand, masked, result, code)
+		if err = cw.Write(row); err != nil {
+			return
+		}
+	}
+
+	cw.Flush()
+	err = cw.Error()
+	return
+}
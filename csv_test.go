@@ -0,0 +1,165 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestValidateCSV(t *testing.T) {
+	input := "" +
+		"id,\"holder, name\",card\n" +
+		"1,\"Doe, John\",4111111111000066\n" +
+		"2,\"say \"\"hi\"\"\",4111-1111-1100-0067\n" +
+		"3,x,411111******0066\n" +
+		"4,\"multi\nline\",card 378282246310005\n"
+	cases := []struct {
+		name   string
+		opts   []CSVOption
+		expect string
+	}{
+		{
+			name: "keep",
+			expect: "" +
+				"id,\"holder, name\",card,brand,masked_pan,result,error\n" +
+				"1,\"Doe, John\",4111111111000066,VISA,411111******0066,valid,\n" +
+				"2,\"say \"\"hi\"\"\",4111-1111-1100-0067,VISA,411111******0067,invalid,\n" +
+				"3,x,411111******0066,VISA,411111******0066,masked,\n" +
+				"4,\"multi\nline\",card 378282246310005,,,malformed,unsupported_length\n",
+		},
+		{
+			name: "mask",
+			opts: []CSVOption{CSVMaskPAN()},
+			expect: "" +
+				"id,\"holder, name\",card,brand,masked_pan,result,error\n" +
+				"1,\"Doe, John\",411111******0066,VISA,411111******0066,valid,\n" +
+				"2,\"say \"\"hi\"\"\",411111******0067,VISA,411111******0067,invalid,\n" +
+				"3,x,411111******0066,VISA,411111******0066,masked,\n" +
+				"4,\"multi\nline\",card 378282*****0005,,,malformed,unsupported_length\n",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			s, err := ValidateCSV(strings.NewReader(input), buf, "card", c.opts...)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if actual := buf.String(); actual != c.expect {
+				t.Log("expect:", c.expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+			if s.Total != 4 || s.Valid != 1 || s.Masked != 1 || s.Full != 2 {
+				t.Fatalf("unexpected summary: %+v", s)
+			}
+		})
+	}
+}
+
+func TestValidateCSVError(t *testing.T) {
+	input := "" +
+		"card\n" +
+		"4111111111000066\n" +
+		"\"\"\n" +
+		"4111-11a1-1111-1111\n" +
+		"4111-1111-1111-1111-1111\n" +
+		"41111111111111111111\n"
+	expect := "" +
+		"card,brand,masked_pan,result,error\n" +
+		"4111111111000066,VISA,411111******0066,valid,\n" +
+		",,,malformed,unsupported_length\n" +
+		"4111-11a1-1111-1111,,,malformed,section\n" +
+		"4111-1111-1111-1111-1111,,,malformed,section\n" +
+		"41111111111111111111,,,malformed,unsupported_length\n"
+	buf := &bytes.Buffer{}
+	if _, err := ValidateCSV(strings.NewReader(input), buf, "card"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if actual := buf.String(); actual != expect {
+		t.Log("expect:", expect)
+		t.Log("actual:", actual)
+		t.Fatal("unexpected result")
+	}
+
+	cases := []struct {
+		err    error
+		expect string
+	}{
+		{newErrUnsupportedLength(20, variableLengths), CSVErrorUnsupportedLength},
+		{fmt.Errorf("wrapped: %w", newErrUnsupportedLength(0, variableLengths)), CSVErrorUnsupportedLength},
+		{ErrRaw, CSVErrorRaw},
+		{ErrSection, CSVErrorSection},
+		{ErrSectionValue{Index: 1, Width: 4}, CSVErrorSection},
+		{ErrMasked, CSVErrorMasked},
+		{io.ErrUnexpectedEOF, CSVErrorOther},
+	}
+	for _, c := range cases {
+		if actual := csvError(c.err); actual != c.expect {
+			t.Log("expect:", c.expect)
+			t.Log("actual:", actual)
+			t.Fatal("unexpected code of", c.err)
+		}
+	}
+}
+
+func TestValidateCSVHeader(t *testing.T) {
+	for _, input := range []string{"", "id,pan\n1,4111111111000066\n"} {
+		_, err := ValidateCSV(strings.NewReader(input), ioutil.Discard, "card")
+		if err != ErrCSVColumn {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+}
+
+func TestValidateCSVStream(t *testing.T) {
+	const rows = 5000
+	r, w := io.Pipe()
+	go func() {
+		bw := bufio.NewWriter(w)
+		fmt.Fprintln(bw, "id,card")
+		for i := 0; i < rows; i++ {
+			fmt.Fprintf(bw, "%d,4111111111%06d\n", i, i)
+		}
+		bw.Flush()
+		w.Close()
+	}()
+
+	lines := 0
+	out := &lineCounter{n: &lines}
+	s, err := ValidateCSV(r, out, "card", CSVMaskPAN())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if lines != rows+1 {
+		t.Fatal("unexpected number of lines:", lines)
+	}
+	if s.Total != rows || s.Full != rows || s.CardTypes[VISACard] != rows {
+		t.Fatalf("unexpected summary: %+v", s)
+	}
+	if s.Valid == 0 || s.Valid == rows {
+		t.Fatal("unexpected number of valid pans:", s.Valid)
+	}
+}
+
+// lineCounter counts lines written to it, and checks no raw pan is written
+type lineCounter struct {
+	n *int
+}
+
+func (c *lineCounter) Write(b []byte) (ret int, err error) {
+	if ContainsPAN(b) {
+		return 0, fmt.Errorf("raw pan is written: %q", b)
+	}
+	*c.n += bytes.Count(b, []byte("\n"))
+	return len(b), nil
+}
@@ -0,0 +1,50 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+// length of card security code of brands without specific rule
+const defaultCVVLength = 3
+
+// Possible errors returned by CardType.ValidateCVV
+const (
+	ErrCVVCardType ErrArgument = "cannot validate security code of unknown card type"
+	ErrCVVLength   ErrArgument = "security code has incorrect length"
+	ErrCVVDigit    ErrArgument = "security code must be composed by digits"
+)
+
+// CVVLength returns length of card security code (CVV, CVC, CID...) of t, like
+// 4 for AMEX and 3 for most brands
+//
+// It returns 0 for brands without security code like UATP, and -1 for unknown
+// card types. Brands without specific rule, including ones registered by
+// RegisterMatcher, use 3.
+func (t CardType) CVVLength() (ret int) {
+	if !t.Known() {
+		return -1
+	}
+	if b, ok := brands[t]; ok {
+		return b.cvcLength
+	}
+	return defaultCVVLength
+}
+
+// ValidateCVV checks if cvv is a card security code of t
+//
+// It checks only length and digits, see CVVLength.
+func (t CardType) ValidateCVV(cvv string) (err error) {
+	l := t.CVVLength()
+	if l < 0 {
+		return ErrCVVCardType
+	}
+	if len(cvv) != l {
+		return ErrCVVLength
+	}
+	for idx := 0; idx < len(cvv); idx++ {
+		if !isDigit(cvv[idx]) {
+			return ErrCVVDigit
+		}
+	}
+	return
+}
@@ -0,0 +1,77 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import "testing"
+
+func TestCVVLength(t *testing.T) {
+	cases := []struct {
+		typ    CardType
+		expect int
+	}{
+		{VISACard, 3},
+		{MasterCard, 3},
+		{JCBCard, 3},
+		{AmericanExpress, 4},
+		{UnionPay, 3},
+		{UATPCard, 0},
+		{UnknownCardType, -1},
+		{endKnownCardType, -1},
+	}
+	for _, c := range cases {
+		if actual := c.typ.CVVLength(); actual != c.expect {
+			t.Log("expect:", c.expect)
+			t.Log("actual:", actual)
+			t.Fatal("unexpected result of", c.typ)
+		}
+	}
+
+	for _, typ := range AllCardTypes() {
+		if l := typ.CVVLength(); l != FrontendHints(typ).CVCLength {
+			t.Fatal("unexpected length of", typ, l)
+		}
+	}
+}
+
+func TestCVVLengthRegistered(t *testing.T) {
+	defer restoreMatchers()()
+
+	gift := mustRegister(t, "gift", 0, func(prefix string) bool { return false })
+	if l := gift.CVVLength(); l != defaultCVVLength {
+		t.Fatal("unexpected length:", l)
+	}
+	if err := gift.ValidateCVV("123"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestValidateCVV(t *testing.T) {
+	cases := []struct {
+		typ    CardType
+		cvv    string
+		expect error
+	}{
+		{VISACard, "123", nil},
+		{VISACard, "000", nil},
+		{VISACard, "1234", ErrCVVLength},
+		{VISACard, "12", ErrCVVLength},
+		{VISACard, "", ErrCVVLength},
+		{VISACard, "12a", ErrCVVDigit},
+		{VISACard, " 12", ErrCVVDigit},
+		{AmericanExpress, "1234", nil},
+		{AmericanExpress, "123", ErrCVVLength},
+		{AmericanExpress, "１２３", ErrCVVLength},
+		{UATPCard, "", nil},
+		{UATPCard, "123", ErrCVVLength},
+		{UnknownCardType, "123", ErrCVVCardType},
+	}
+	for _, c := range cases {
+		if actual := c.typ.ValidateCVV(c.cvv); actual != c.expect {
+			t.Log("expect:", c.expect)
+			t.Log("actual:", actual)
+			t.Fatalf("unexpected result of %s %q", c.typ, c.cvv)
+		}
+	}
+}
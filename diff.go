@@ -0,0 +1,87 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import "strings"
+
+// ErrDiffLength is returned by Diff if two PANs have different length
+const ErrDiffLength ErrArgument = "pans have different length"
+
+// SectionState is the comparison result of a section in Diff
+type SectionState int
+
+// Possible section states
+const (
+	SectionMatch         SectionState = iota // all digits are same
+	SectionMismatch                          // at least one digit differs
+	SectionIndeterminate                     // no known difference, but some digits are masked
+)
+
+func (s SectionState) String() (ret string) {
+	switch s {
+	case SectionMatch:
+		return "match"
+	case SectionMismatch:
+		return "mismatch"
+	case SectionIndeterminate:
+		return "indeterminate"
+	}
+	return "unknown"
+}
+
+// SectionDiff is the comparison result of a section (digit group in PAN())
+type SectionDiff struct {
+	Index int // index of the section, starts from 0
+	State SectionState
+}
+
+// panGroups returns digit groups of x
+func panGroups(x Info) (ret []string) {
+	if i, ok := x.(*info); ok && !i.IsZero() {
+		return i.groups(i.pan)
+	}
+	return strings.FieldsFunc(x.PAN(), func(r rune) bool {
+		return r != '*' && (r < '0' || r > '9')
+	})
+}
+
+// Diff compares a and b section by section, without revealing any digit
+//
+// Sections are digit groups of a, like those in a.PAN(). A digit is compared
+// only if it is unmasked in both a and b, so a section is SectionMismatch if
+// any compared digit differs, SectionIndeterminate if there's no difference
+// but some digit is masked, or SectionMatch otherwise. It returns
+// ErrDiffLength if a and b have different length.
+func Diff(a, b Info) (ret []SectionDiff, err error) {
+	rawA, rawB := a.RawPAN(), b.RawPAN()
+	if len(rawA) != len(rawB) {
+		err = ErrDiffLength
+		return
+	}
+
+	groups := panGroups(a)
+	ret = make([]SectionDiff, 0, len(groups))
+	pos := 0
+	for idx, g := range groups {
+		state := SectionMatch
+		for x := pos; x < pos+len(g); x++ {
+			ca, cb := rawA[x], rawB[x]
+			if ca == '*' || cb == '*' {
+				if state == SectionMatch {
+					state = SectionIndeterminate
+				}
+				continue
+			}
+			if ca != cb {
+				state = SectionMismatch
+				break
+			}
+		}
+		ret = append(ret, SectionDiff{Index: idx, State: state})
+		pos += len(g)
+	}
+
+	return
+}
@@ -0,0 +1,75 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"reflect"
+	"testing"
+)
+
+// shortInfo pretends to be a 15-digit PAN
+type shortInfo struct {
+	Info
+}
+
+func (i shortInfo) RawPAN() (ret string) { return i.Info.RawPAN()[:15] }
+func (i shortInfo) PAN() (ret string)    { return i.Info.PAN()[:18] }
+
+func TestDiff(t *testing.T) {
+	const (
+		M = SectionMatch
+		X = SectionMismatch
+		I = SectionIndeterminate
+	)
+	cases := []struct {
+		a, b   string
+		expect []SectionState
+	}{
+		{"4111111111111111", "4111111111111111", []SectionState{M, M, M, M}},
+		{"4111111111111111", "4111121111111112", []SectionState{M, X, M, X}},
+		{"4111111111111111", "411111******1111", []SectionState{M, I, I, M}},
+		{"411111******1111", "4111111111111111", []SectionState{M, I, I, M}},
+		{"4111111111111111", "411112******1112", []SectionState{M, X, I, X}},
+		{"411111******1111", "555555******4444", []SectionState{X, X, I, X}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.a+"_"+c.b, func(t *testing.T) {
+			a, _ := FromRaw(c.a)
+			b, _ := FromRaw(c.b)
+			diffs, err := Diff(a, b)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			actual := make([]SectionState, 0, len(diffs))
+			for idx, d := range diffs {
+				if d.Index != idx {
+					t.Fatal("unexpected index:", d.Index)
+				}
+				actual = append(actual, d.State)
+			}
+			if !reflect.DeepEqual(actual, c.expect) {
+				t.Log("expect:", c.expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+		})
+	}
+}
+
+func TestDiffLength(t *testing.T) {
+	a, _ := FromRaw("4111111111111111")
+	b := shortInfo{a}
+	if _, err := Diff(a, b); err != ErrDiffLength {
+		t.Log("expect:", ErrDiffLength)
+		t.Log("actual:", err)
+		t.Fatal("unexpected error")
+	}
+	if _, err := Diff(b, a); err != ErrDiffLength {
+		t.Log("expect:", ErrDiffLength)
+		t.Log("actual:", err)
+		t.Fatal("unexpected error")
+	}
+}
@@ -0,0 +1,78 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// zeros of the scripts accepted by WithUnicodeDigits, digits of a script are
+// the 10 code points starting from its zero
+var digitZeros = []rune{
+	'０', // full-width
+	'٠', // Arabic-Indic
+	'۰', // Extended Arabic-Indic
+	'०', // Devanagari
+}
+
+// WithUnicodeDigits makes constructors accept decimal digits of some other
+// scripts, which are converted to ASCII before parsing
+//
+// Supported scripts are full-width forms (０-９), Arabic-Indic (٠-٩), Extended
+// Arabic-Indic (۰-۹) and Devanagari (०-९). Scripts can be mixed in one PAN.
+func WithUnicodeDigits() (ret Option) {
+	return func(c *Config) {
+		c.unicodeDigits = true
+	}
+}
+
+// asciiDigit converts r to ASCII if it is a digit of supported scripts
+func asciiDigit(r rune) (ret rune, ok bool) {
+	if !unicode.IsDigit(r) {
+		return r, false
+	}
+	for _, z := range digitZeros {
+		if r >= z && r <= z+9 {
+			return '0' + r - z, true
+		}
+	}
+	return r, false
+}
+
+// normalizeDigits converts digits of supported scripts in s to ASCII, other
+// characters are kept as is
+func normalizeDigits(s string) (ret string) {
+	idx := 0
+	for idx < len(s) && s[idx] < utf8.RuneSelf {
+		idx++
+	}
+	if idx == len(s) {
+		return s
+	}
+
+	buf := &strings.Builder{}
+	buf.Grow(len(s))
+	buf.WriteString(s[:idx])
+	for _, r := range s[idx:] {
+		r, _ = asciiDigit(r)
+		buf.WriteRune(r)
+	}
+	return buf.String()
+}
+
+// normalize applies WithUnicodeDigits and WithOCRCorrections to s, returns
+// number of OCR corrections
+func (c *Config) normalize(s string) (ret string, n int) {
+	ret = s
+	if c.unicodeDigits {
+		ret = normalizeDigits(ret)
+	}
+	if c.ocrCorrections {
+		ret, n = correctOCR(ret)
+	}
+	return
+}
@@ -0,0 +1,54 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUnicodeDigits(t *testing.T) {
+	c := New(WithUnicodeDigits())
+	cases := []struct {
+		name string
+		pan  string
+	}{
+		{"ascii", "4111111111000066"},
+		{"full-width", "４１１１１１１１１１００００６６"},
+		{"arabic-indic", "٤١١١١١١١١١٠٠٠٠٦٦"},
+		{"extended-arabic-indic", "۴۱۱۱۱۱۱۱۱۱۰۰۰۰۶۶"},
+		{"devanagari", "४१११११११११००००६६"},
+		{"devanagari-dashed", "४१११-११११-११००-००६६"},
+		{"mixed", "4111-١١١١-11٠٠-0066"},
+	}
+
+	for _, x := range cases {
+		t.Run(x.name, func(t *testing.T) {
+			info, err := c.Parse(x.pan)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if actual := info.RawPAN(); actual != "4111111111000066" {
+				t.Log("expect:", "4111111111000066")
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+		})
+	}
+
+	info, err := c.FromMasked("٤١١١١١", "٠٠٦٦")
+	if err != nil || info.RawPAN() != "411111******0066" {
+		t.Fatal("unexpected result:", info, err)
+	}
+
+	// disabled by default
+	if _, err := Parse("٤١١١١١١١١١٠٠٠٠٦٦"); !errors.Is(err, ErrRaw) {
+		t.Fatal("unexpected error:", err)
+	}
+	// digits of other scripts are not accepted
+	if _, err := c.Parse("๔๑๑๑๑๑๑๑๑๑๐๐๐๐๖๖"); !errors.Is(err, ErrRaw) {
+		t.Fatal("unexpected error:", err)
+	}
+}
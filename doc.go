@@ -4,5 +4,7 @@
 
 // Package creditcard provides few helper to operate on PAN
 //
-// Currently only 16 digit PANs are supported
+// PANs of 12 to 19 digits are supported. Grouped PANs passed to FromSlice
+// (and FromDashed) are limited to 14 (Diners Club), 15 (AMEX) and 16 digits
+// by default, other lengths are accepted if WithVariableLength is used
 package creditcard
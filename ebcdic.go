@@ -0,0 +1,108 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"strconv"
+	"strings"
+)
+
+// EBCDICCodePage denotes an EBCDIC code page
+type EBCDICCodePage int
+
+// Supported code pages
+const (
+	CP037 EBCDICCodePage = iota + 1 // USA/Canada
+	CP500                           // International
+)
+
+// ErrEBCDICCodePage is returned by ParseEBCDIC and EncodeEBCDIC if the code
+// page is not supported
+const ErrEBCDICCodePage ErrArgument = "unsupported ebcdic code page"
+
+// ErrEBCDICByte is returned by ParseEBCDIC and EncodeEBCDIC if a byte cannot
+// be mapped
+type ErrEBCDICByte struct {
+	Offset int  // offset of the byte, starts from 0
+	Value  byte // the byte
+}
+
+func (e ErrEBCDICByte) Error() (ret string) {
+	return "creditcard: invalid argument: unmappable byte 0x" +
+		strconv.FormatUint(uint64(e.Value), 16) + " at offset " +
+		strconv.Itoa(e.Offset)
+}
+
+// ascii to ebcdic of characters used in PAN fields, they are same in CP037
+// and CP500
+var ebcdicPAN = map[byte]byte{
+	'0': 0xf0, '1': 0xf1, '2': 0xf2, '3': 0xf3, '4': 0xf4,
+	'5': 0xf5, '6': 0xf6, '7': 0xf7, '8': 0xf8, '9': 0xf9,
+	' ': 0x40, '*': 0x5c, '-': 0x60,
+}
+
+type ebcdicTable struct {
+	decode [256]byte // 0 means unmappable
+	encode [256]byte // 0 means unmappable
+}
+
+var ebcdicTables = map[EBCDICCodePage]*ebcdicTable{
+	CP037: newEBCDICTable(ebcdicPAN),
+	CP500: newEBCDICTable(ebcdicPAN),
+}
+
+func newEBCDICTable(m map[byte]byte) (ret *ebcdicTable) {
+	ret = &ebcdicTable{}
+	for a, e := range m {
+		ret.decode[e] = a
+		ret.encode[a] = e
+	}
+	return
+}
+
+func convertEBCDIC(b []byte, table *[256]byte) (ret []byte, err error) {
+	ret = make([]byte, len(b))
+	for idx, c := range b {
+		if ret[idx] = table[c]; ret[idx] == 0 {
+			return nil, ErrEBCDICByte{Offset: idx, Value: c}
+		}
+	}
+	return
+}
+
+// ParseEBCDIC parses an EBCDIC encoded PAN field by Parse
+//
+// Only digits, spaces, asterisks and dashes are mapped, leading and trailing
+// spaces (pad) are trimmed. It returns ErrEBCDICByte for other bytes.
+func ParseEBCDIC(b []byte, cp EBCDICCodePage) (ret Info, err error) {
+	return defaultConfig.ParseEBCDIC(b, cp)
+}
+
+// ParseEBCDIC is same as package-level ParseEBCDIC, but uses c
+func (c *Config) ParseEBCDIC(b []byte, cp EBCDICCodePage) (ret Info, err error) {
+	t, ok := ebcdicTables[cp]
+	if !ok {
+		return nil, ErrEBCDICCodePage
+	}
+	buf, err := convertEBCDIC(b, &t.decode)
+	if err != nil {
+		return
+	}
+	return c.Parse(strings.Trim(string(buf), " "))
+}
+
+// EncodeEBCDIC encodes canonical form (see Info.Canonical) of info in EBCDIC
+//
+// It returns ErrNoCard if info is NoCard.
+func EncodeEBCDIC(info Info, cp EBCDICCodePage) (ret []byte, err error) {
+	t, ok := ebcdicTables[cp]
+	if !ok {
+		return nil, ErrEBCDICCodePage
+	}
+	if info == nil || info.IsZero() {
+		return nil, ErrNoCard
+	}
+	return convertEBCDIC([]byte(info.Canonical()), &t.encode)
+}
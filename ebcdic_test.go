@@ -0,0 +1,75 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEBCDIC(t *testing.T) {
+	raw := []byte{0xf4, 0xf1, 0xf1, 0xf1, 0xf1, 0xf1, 0xf1, 0xf1, 0xf1, 0xf1, 0xf0, 0xf0, 0xf0, 0xf0, 0xf6, 0xf6}
+	padded := append(append([]byte{0x40, 0x40}, raw...), 0x40)
+	dashed := []byte{0xf4, 0xf1, 0xf1, 0xf1, 0x60, 0xf1, 0xf1, 0x5c, 0x5c, 0x60, 0x5c, 0x5c, 0x5c, 0x5c, 0x60, 0xf0, 0xf0, 0xf6, 0xf6}
+	cases := []struct {
+		name   string
+		cp     EBCDICCodePage
+		input  []byte
+		expect string
+	}{
+		{"cp037", CP037, raw, "4111111111000066"},
+		{"cp500", CP500, raw, "4111111111000066"},
+		{"padded", CP037, padded, "4111111111000066"},
+		{"dashed", CP500, dashed, "411111******0066"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			info, err := ParseEBCDIC(c.input, c.cp)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if actual := info.RawPAN(); actual != c.expect {
+				t.Log("expect:", c.expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+
+			b, err := EncodeEBCDIC(info, c.cp)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if again, err := ParseEBCDIC(b, c.cp); err != nil || again.RawPAN() != c.expect {
+				t.Fatal("unexpected round trip result:", b, err)
+			}
+			if c.name != "dashed" && c.name != "padded" && !bytes.Equal(b, c.input) {
+				t.Log("expect:", c.input)
+				t.Log("actual:", b)
+				t.Fatal("unexpected encoded result")
+			}
+		})
+	}
+}
+
+func TestEBCDICError(t *testing.T) {
+	corrupted := []byte{0xf4, 0xf1, 0xf1, 0xf1, 0xf1, 0xf1, 0x34, 0xf1}
+	_, err := ParseEBCDIC(corrupted, CP037)
+	if expect := (ErrEBCDICByte{Offset: 6, Value: 0x34}); err != expect {
+		t.Log("expect:", expect)
+		t.Log("actual:", err)
+		t.Fatal("unexpected error")
+	}
+
+	// ascii digits are not ebcdic
+	if _, err = ParseEBCDIC([]byte("4111111111000066"), CP500); err != (ErrEBCDICByte{Offset: 0, Value: '4'}) {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, err = ParseEBCDIC(nil, 0); err != ErrEBCDICCodePage {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, err = EncodeEBCDIC(NoCard, CP037); err != ErrNoCard {
+		t.Fatal("unexpected error:", err)
+	}
+}
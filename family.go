@@ -0,0 +1,20 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+// sub-brands and the brand they belong to
+var brandFamilies = map[CardType]CardType{
+	VisaElectron: VISACard,
+}
+
+// Family returns the brand t belongs to, like VISACard for VisaElectron
+//
+// It returns t itself if t is not a sub-brand.
+func (t CardType) Family() (ret CardType) {
+	if f, ok := brandFamilies[t]; ok {
+		return f
+	}
+	return t
+}
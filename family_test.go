@@ -0,0 +1,66 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import "testing"
+
+func TestVisaElectron(t *testing.T) {
+	cases := map[string]CardType{
+		"4026000000000002": VisaElectron,
+		"4175001234567890": VisaElectron,
+		"4175011234567890": VISACard,
+		"4176001234567890": VISACard,
+		"4508000000000000": VisaElectron,
+		"4844000000000000": VisaElectron,
+		"4913000000000000": VisaElectron,
+		"4917000000000000": VisaElectron,
+		"4916000000000000": VISACard,
+		"4111111111111111": VISACard,
+	}
+	for pan, expect := range cases {
+		info, err := FromRaw(pan)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if actual := info.CardType(); actual != expect {
+			t.Log("expect:", expect)
+			t.Log("actual:", actual)
+			t.Fatal("unexpected card type of", pan)
+		}
+		if info.CardType().Family() != VISACard {
+			t.Fatal("unexpected family of", pan)
+		}
+	}
+
+	// only 4 digits are known
+	info, _ := FromSlice([]string{"4175"})
+	if info.CardType() != VISACard {
+		t.Fatal("unexpected card type:", info.CardType())
+	}
+}
+
+func TestFamily(t *testing.T) {
+	for _, typ := range AllCardTypes() {
+		f := typ.Family()
+		if !f.Known() || f.Family() != f {
+			t.Fatal("unexpected family of", typ, f)
+		}
+	}
+	if UnknownCardType.Family() != UnknownCardType {
+		t.Fatal("unexpected family of unknown card type")
+	}
+
+	info, _ := FromRaw("4175001234567890")
+	if err := Acceptance(VISACard).Validate(info); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := Acceptance(VisaElectron).Validate(info); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	visa, _ := FromRaw("4111111111111111")
+	if err := Acceptance(VisaElectron).Validate(visa); err != ErrNotAccepted {
+		t.Fatal("unexpected error:", err)
+	}
+}
@@ -0,0 +1,90 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+// SeparatorStyle denotes how digit groups of a PAN are separated
+type SeparatorStyle int
+
+// Possible separator styles
+const (
+	SeparatorNone  SeparatorStyle = iota // "4111111111111111"
+	SeparatorSpace                       // "4111 1111 1111 1111"
+	SeparatorDash                        // "4111-1111-1111-1111"
+	SeparatorMixed                       // "4111 1111-1111 1111"
+)
+
+// Finding is a PAN-like sequence found by FindAll
+type Finding struct {
+	Start     int    // byte offset where it starts
+	End       int    // byte offset where it ends, exclusive
+	Masked    string // the sequence with middle digits masked, see Redact
+	CardType  CardType
+	Luhn      bool // passes luhn check
+	Separator SeparatorStyle
+}
+
+func newFinding(s string, start, end int, luhn bool) (ret Finding) {
+	ret = Finding{Start: start, End: end, Luhn: luhn}
+	b := []byte(s[start:end])
+	digits := make([]byte, 0, len(b))
+	for idx, c := range b {
+		if isDigit(c) {
+			digits = append(digits, c)
+			continue
+		}
+		style := SeparatorSpace
+		if c == '-' {
+			style = SeparatorDash
+		}
+		switch {
+		case idx == 0:
+		case ret.Separator == SeparatorNone:
+			ret.Separator = style
+		case ret.Separator != style:
+			ret.Separator = SeparatorMixed
+		}
+	}
+	maskPANText(b)
+	ret.Masked = string(b)
+	ret.CardType = defaultConfig.cardType(string(digits))
+	return
+}
+
+// FindAll reports every sequence of 13-19 digits in s, see ScanPANs for the
+// format of such sequences
+//
+// Unlike ScanPANs, luhn-invalid sequences are reported too, with Luhn set to
+// false. If candidates overlap, luhn-valid ones win, then the longest one.
+// Digit runs are never split, so a run of 20 digits is ignored as a whole
+// even if it contains a valid PAN.
+func FindAll(s string) (ret []Finding) {
+	b := []byte(s)
+	// b[pos:limit] is the gap before next luhn-valid sequence
+	for pos := 0; pos < len(b); {
+		start, end, _ := defaultMatcher.find(b[pos:], true)
+		limit := len(b)
+		if start >= 0 {
+			start, end = start+pos, end+pos
+			limit = start
+		}
+
+		for gap := b[pos:limit]; len(gap) > 0; {
+			s2, e2, _ := forceMatcher.find(gap, true)
+			if s2 < 0 {
+				break
+			}
+			off := limit - len(gap)
+			ret = append(ret, newFinding(s, off+s2, off+e2, false))
+			gap = gap[e2:]
+		}
+
+		if start < 0 {
+			break
+		}
+		ret = append(ret, newFinding(s, start, end, true))
+		pos = end
+	}
+	return
+}
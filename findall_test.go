@@ -0,0 +1,87 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindAll(t *testing.T) {
+	cases := []struct {
+		input  string
+		expect []Finding
+	}{
+		{
+			"no pan 1234",
+			nil,
+		},
+		{
+			"a 4111111111111111,5555555555554444 b",
+			[]Finding{
+				{2, 18, "411111******1111", VISACard, true, SeparatorNone},
+				{19, 35, "555555******4444", MasterCard, true, SeparatorNone},
+			},
+		},
+		{
+			"4111 1111 1111 1111 5555-5555-5555-4444",
+			[]Finding{
+				{0, 19, "4111 11** **** 1111", VISACard, true, SeparatorSpace},
+				{20, 39, "5555-55**-****-4444", MasterCard, true, SeparatorDash},
+			},
+		},
+		{
+			"id 4111111111111112 and 3782-822463 10005",
+			[]Finding{
+				{3, 19, "411111******1112", VISACard, false, SeparatorNone},
+				{24, 41, "3782-82**** *0005", AmericanExpress, true, SeparatorMixed},
+			},
+		},
+		{
+			// 16-digit valid PAN followed by a group, 19 digits are invalid
+			"4111 1111 1111 1111 123",
+			[]Finding{
+				{0, 19, "4111 11** **** 1111", VISACard, true, SeparatorSpace},
+			},
+		},
+		{
+			// invalid prefix group followed by a valid PAN
+			"123 4111 1111 1111 1111",
+			[]Finding{
+				{4, 23, "4111 11** **** 1111", VISACard, true, SeparatorSpace},
+			},
+		},
+		{
+			// brands detected by 6 digits
+			"elo 4011780000000006 electron 4175000000000001",
+			[]Finding{
+				{4, 20, "401178******0006", EloCard, true, SeparatorNone},
+				{30, 46, "417500******0001", VisaElectron, true, SeparatorNone},
+			},
+		},
+		{
+			// valid PAN inside a longer digit run
+			"x 41111111111111111111 y",
+			nil,
+		},
+		{
+			"x 4111111111111111123 y",
+			[]Finding{
+				{2, 21, "411111*********1123", VISACard, false, SeparatorNone},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			actual := FindAll(c.input)
+			if !reflect.DeepEqual(actual, c.expect) {
+				t.Log("expect:", c.expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+		})
+	}
+}
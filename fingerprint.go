@@ -0,0 +1,166 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"strings"
+)
+
+// ErrFingerprintFormat is returned by Fingerprinter.Matches if the stored
+// fingerprint is malformed
+const ErrFingerprintFormat ErrArgument = "malformed fingerprint"
+
+func fingerprint(h hash.Hash, info Info) (ret string, err error) {
+	if info == nil || info.IsZero() {
+		err = ErrNoCard
+		return
+	}
+	pan := info.RawPAN()
+	if strings.Contains(pan, "*") {
+		err = ErrMaskedPAN
+		return
+	}
+
+	h.Reset()
+	h.Write([]byte(pan))
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Fingerprint computes HMAC-SHA256 of raw PAN with key, in lower-case hex
+//
+// It returns ErrMaskedPAN if info is masked, or ErrNoCard if info is nil or
+// NoCard.
+func Fingerprint(info Info, key []byte) (ret string, err error) {
+	return fingerprint(hmac.New(sha256.New, key), info)
+}
+
+// FingerprintResult is the result of a PAN in FingerprintStream
+type FingerprintResult struct {
+	Index       int    // index of the PAN in input, starts from 0
+	Fingerprint string // empty if Err is not nil
+	Err         error  // error from FromRaw or Fingerprint
+}
+
+// FingerprintStream reads raw PANs from in, computes fingerprints and sends
+// results to out
+//
+// Invalid PANs are reported by FingerprintResult.Err, they do not stop the
+// stream. It blocks until in is closed or ctx is done, and closes out before
+// returning. Raw PANs are not retained after sent to out.
+func FingerprintStream(ctx context.Context, key []byte, in <-chan string, out chan<- FingerprintResult) (err error) {
+	defer close(out)
+	h := hmac.New(sha256.New, key)
+	for idx := 0; ; idx++ {
+		var pan string
+		var ok bool
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case pan, ok = <-in:
+			if !ok {
+				return
+			}
+		}
+
+		res := FingerprintResult{Index: idx}
+		var info Info
+		if info, res.Err = FromRaw(pan); res.Err == nil {
+			res.Fingerprint, res.Err = fingerprint(h, info)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case out <- res:
+		}
+	}
+}
+
+type fingerprintKey struct {
+	id  string
+	key []byte
+}
+
+// fingerprintKeyID identifies a key without exposing it
+func fingerprintKeyID(key []byte) (ret string) {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte("creditcard fingerprint key id"))
+	return hex.EncodeToString(h.Sum(nil)[:4])
+}
+
+// Fingerprinter computes and verifies fingerprints with key rotation support
+//
+// Fingerprints created by it are in the form of "kid:mac", where kid is 8 hex
+// digits identifying the key (derived from the key, it does not reveal the
+// key) and mac is the result of Fingerprint with that key. The format is
+// stable. It is safe for concurrent use.
+type Fingerprinter struct {
+	keys []fingerprintKey
+}
+
+// NewFingerprinter creates a Fingerprinter which uses current key to create
+// fingerprints, and previous keys (in order) to verify old fingerprints
+func NewFingerprinter(current []byte, previous ...[]byte) (ret *Fingerprinter) {
+	ret = &Fingerprinter{keys: make([]fingerprintKey, 0, len(previous)+1)}
+	for _, k := range append([][]byte{current}, previous...) {
+		ret.keys = append(ret.keys, fingerprintKey{
+			id:  fingerprintKeyID(k),
+			key: k,
+		})
+	}
+	return
+}
+
+// Fingerprint computes fingerprint of info with current key
+//
+// It returns ErrMaskedPAN if info is masked.
+func (f *Fingerprinter) Fingerprint(info Info) (ret string, err error) {
+	k := f.keys[0]
+	if ret, err = Fingerprint(info, k.key); err != nil {
+		return
+	}
+	return k.id + ":" + ret, nil
+}
+
+// Matches reports if stored is a fingerprint of info
+//
+// If stored carries a key id, only that key is tried. Fingerprints without
+// key id (created by package-level Fingerprint) are tried against current key
+// and then previous keys. Comparison is done in constant time.
+func (f *Fingerprinter) Matches(info Info, stored string) (ret bool, err error) {
+	id := ""
+	if idx := strings.IndexByte(stored, ':'); idx >= 0 {
+		id, stored = stored[:idx], stored[idx+1:]
+	}
+	mac, err := hex.DecodeString(stored)
+	if err != nil || len(mac) != sha256.Size {
+		return false, ErrFingerprintFormat
+	}
+
+	if info.IsZero() {
+		return false, ErrNoCard
+	}
+	pan := info.RawPAN()
+	if strings.Contains(pan, "*") {
+		return false, ErrMaskedPAN
+	}
+	for _, k := range f.keys {
+		if id != "" && id != k.id {
+			continue
+		}
+		h := hmac.New(sha256.New, k.key)
+		h.Write([]byte(pan))
+		if hmac.Equal(h.Sum(nil), mac) {
+			return true, nil
+		}
+	}
+
+	return
+}
@@ -0,0 +1,146 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// ErrBulkFingerprint is returned by Fingerprinter.Bulk if some entries cannot
+// be fingerprinted
+type ErrBulkFingerprint struct {
+	Errors map[int]error // errors like ErrMaskedPAN, keyed by index of entry
+}
+
+func (e *ErrBulkFingerprint) Error() (ret string) {
+	idx := make([]int, 0, len(e.Errors))
+	for i := range e.Errors {
+		idx = append(idx, i)
+	}
+	sort.Ints(idx)
+	return "creditcard: " + strconv.Itoa(len(idx)) +
+		" entries cannot be fingerprinted, first at index " +
+		strconv.Itoa(idx[0]) + ": " + e.Errors[idx[0]].Error()
+}
+
+// bulkFingerprinter holds buffers reused between entries
+type bulkFingerprinter struct {
+	id  string
+	h   hash.Hash
+	pan []byte
+	mac []byte
+	out []byte
+}
+
+func (f *Fingerprinter) newBulk() (ret *bulkFingerprinter) {
+	k := f.keys[0]
+	return &bulkFingerprinter{
+		id:  k.id,
+		h:   hmac.New(sha256.New, k.key),
+		mac: make([]byte, 0, sha256.Size),
+		out: make([]byte, len(k.id)+1+sha256.Size*2),
+	}
+}
+
+func (b *bulkFingerprinter) fingerprint(x Info) (ret string, err error) {
+	if x == nil || x.IsZero() {
+		return "", ErrNoCard
+	}
+	b.pan = append(b.pan[:0], x.RawPAN()...)
+	for _, c := range b.pan {
+		if c == '*' {
+			return "", ErrMaskedPAN
+		}
+	}
+
+	b.h.Reset()
+	b.h.Write(b.pan)
+	b.mac = b.h.Sum(b.mac[:0])
+	n := copy(b.out, b.id)
+	b.out[n] = ':'
+	hex.Encode(b.out[n+1:], b.mac)
+	return string(b.out), nil
+}
+
+// Bulk is same as calling Fingerprint for every entry of infos, but reuses
+// internal states, so it is much faster and allocates less
+//
+// Results are written into dst, which is grown if its capacity is not
+// enough, and returned. Entries failed to fingerprint get empty string, and
+// their errors are reported by an *ErrBulkFingerprint.
+func (f *Fingerprinter) Bulk(infos []Info, dst []string) (ret []string, err error) {
+	return f.BulkConcurrent(infos, dst, 1)
+}
+
+// BulkConcurrent is like Bulk, but splits infos into workers parts and
+// processes them concurrently
+//
+// workers < 1 is treated as 1.
+func (f *Fingerprinter) BulkConcurrent(infos []Info, dst []string, workers int) (ret []string, err error) {
+	if cap(dst) >= len(infos) {
+		ret = dst[:len(infos)]
+	} else {
+		ret = make([]string, len(infos))
+	}
+	if workers > len(infos) {
+		workers = len(infos)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	errs := make([]map[int]error, workers)
+	run := func(w, begin, end int) {
+		b := f.newBulk()
+		for idx := begin; idx < end; idx++ {
+			var e error
+			if ret[idx], e = b.fingerprint(infos[idx]); e != nil {
+				if errs[w] == nil {
+					errs[w] = map[int]error{}
+				}
+				errs[w][idx] = e
+			}
+		}
+	}
+
+	if workers <= 1 {
+		run(0, 0, len(infos))
+	} else {
+		size := (len(infos) + workers - 1) / workers
+		wg := &sync.WaitGroup{}
+		for w := 0; w < workers; w++ {
+			begin, end := w*size, (w+1)*size
+			if end > len(infos) {
+				end = len(infos)
+			}
+			wg.Add(1)
+			go func(w, begin, end int) {
+				defer wg.Done()
+				run(w, begin, end)
+			}(w, begin, end)
+		}
+		wg.Wait()
+	}
+
+	var all map[int]error
+	for _, m := range errs {
+		for idx, e := range m {
+			if all == nil {
+				all = map[int]error{}
+			}
+			all[idx] = e
+		}
+	}
+	if all != nil {
+		err = &ErrBulkFingerprint{Errors: all}
+	}
+	return
+}
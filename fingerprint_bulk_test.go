@@ -0,0 +1,91 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func bulkInput(n int) (ret []Info) {
+	ret = make([]Info, n)
+	for idx := range ret {
+		ret[idx], _ = FromRaw(fmt.Sprintf("4111111111%06d", idx))
+	}
+	return
+}
+
+func TestFingerprinterBulk(t *testing.T) {
+	f := NewFingerprinter([]byte("key"), []byte("old"))
+	infos := bulkInput(100)
+	masked, _ := FromMasked("411111", "1111")
+	infos[3], infos[50], infos[99] = masked, NoCard, nil
+
+	expect := make([]string, len(infos))
+	for idx, info := range infos {
+		if info != nil {
+			expect[idx], _ = f.Fingerprint(info)
+		}
+	}
+	expectErr := &ErrBulkFingerprint{Errors: map[int]error{
+		3:  ErrMaskedPAN,
+		50: ErrNoCard,
+		99: ErrNoCard,
+	}}
+
+	for _, workers := range []int{0, 1, 3, 8, 200} {
+		t.Run(strconv.Itoa(workers), func(t *testing.T) {
+			dst := make([]string, 0, len(infos))
+			actual, err := f.BulkConcurrent(infos, dst, workers)
+			if !reflect.DeepEqual(err, expectErr) {
+				t.Log("expect:", expectErr)
+				t.Log("actual:", err)
+				t.Fatal("unexpected error")
+			}
+			if !reflect.DeepEqual(actual, expect) {
+				t.Log("expect:", expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+			if &actual[0] != &dst[:1][0] {
+				t.Fatal("dst is not reused")
+			}
+		})
+	}
+
+	var e *ErrBulkFingerprint
+	if _, err := f.Bulk(infos, nil); !errors.As(err, &e) || len(e.Errors) != 3 {
+		t.Fatal("unexpected error:", err)
+	}
+	if ret, err := f.Bulk(nil, nil); err != nil || len(ret) != 0 {
+		t.Fatal("unexpected result:", ret, err)
+	}
+}
+
+func BenchmarkFingerprinterScalar(b *testing.B) {
+	f := NewFingerprinter([]byte("key"))
+	infos := bulkInput(1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, info := range infos {
+			f.Fingerprint(info)
+		}
+	}
+}
+
+func BenchmarkFingerprinterBulk(b *testing.B) {
+	f := NewFingerprinter([]byte("key"))
+	infos := bulkInput(1000)
+	dst := make([]string, len(infos))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst, _ = f.Bulk(infos, dst)
+	}
+}
@@ -0,0 +1,189 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFingerprint(t *testing.T) {
+	info, _ := FromRaw("4111111111111111")
+	actual, err := Fingerprint(info, []byte("key"))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	expect := "d7deb2f89396a762e0f485ff629bed80118728d1ef5d5744f870a99001a1437d"
+	if actual != expect {
+		t.Log("expect:", expect)
+		t.Log("actual:", actual)
+		t.Fatal("unexpected result")
+	}
+
+	masked, _ := FromMasked("411111", "1111")
+	if _, err := Fingerprint(masked, []byte("key")); err != ErrMaskedPAN {
+		t.Log("expect:", ErrMaskedPAN)
+		t.Log("actual:", err)
+		t.Fatal("unexpected error")
+	}
+
+	for _, x := range []Info{nil, NoCard} {
+		if _, err := Fingerprint(x, []byte("key")); err != ErrNoCard {
+			t.Log("expect:", ErrNoCard)
+			t.Log("actual:", err)
+			t.Fatalf("unexpected error of %#v", x)
+		}
+	}
+}
+
+func TestFingerprintStream(t *testing.T) {
+	key := []byte("key")
+	input := []string{
+		"4111111111111111",
+		"bad",
+		"5555555555554444",
+		"411111******1111",
+		"4012888888881881",
+	}
+	in := make(chan string)
+	out := make(chan FingerprintResult) // unbuffered, result is not read in background
+	go func() {
+		defer close(in)
+		for _, pan := range input {
+			in <- pan
+		}
+	}()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- FingerprintStream(context.Background(), key, in, out)
+	}()
+
+	idx := 0
+	for res := range out {
+		if res.Index != idx {
+			t.Log("expect:", idx)
+			t.Log("actual:", res.Index)
+			t.Fatal("unexpected index")
+		}
+		info, err := FromRaw(input[idx])
+		if err == nil {
+			var fp string
+			fp, err = Fingerprint(info, key)
+			if res.Fingerprint != fp {
+				t.Log("expect:", fp)
+				t.Log("actual:", res.Fingerprint)
+				t.Fatal("unexpected fingerprint")
+			}
+		}
+		if !reflect.DeepEqual(res.Err, err) {
+			t.Log("expect:", err)
+			t.Log("actual:", res.Err)
+			t.Fatal("unexpected error")
+		}
+		idx++
+	}
+	if idx != len(input) {
+		t.Fatal("unexpected result count:", idx)
+	}
+	if err := <-done; err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestFingerprintStreamCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan string, 1)
+	out := make(chan FingerprintResult)
+	in <- "4111111111111111"
+
+	done := make(chan error, 1)
+	go func() {
+		done <- FingerprintStream(ctx, []byte("key"), in, out)
+	}()
+
+	// nobody reads out, stream must be blocked until canceled
+	select {
+	case err := <-done:
+		t.Fatal("unexpected return:", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Log("expect:", context.Canceled)
+			t.Log("actual:", err)
+			t.Fatal("unexpected error")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("not canceled")
+	}
+	if _, ok := <-out; ok {
+		t.Fatal("out is not closed")
+	}
+}
+
+func TestFingerprinter(t *testing.T) {
+	info, _ := FromRaw("4111111111111111")
+	other, _ := FromRaw("5555555555554444")
+	masked, _ := FromMasked("411111", "1111")
+
+	oldKey, newKey := []byte("key2025"), []byte("key2026")
+	old := NewFingerprinter(oldKey)
+	rotated := NewFingerprinter(newKey, oldKey)
+
+	fp, err := rotated.Fingerprint(info)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	expect := "195d70bb:b4c7a1dc1276a68aaecd3edd31c45557e04b317740255100bfada31e778ad30a"
+	if fp != expect {
+		t.Log("expect:", expect)
+		t.Log("actual:", fp)
+		t.Fatal("fingerprint format changed")
+	}
+
+	oldFP, _ := old.Fingerprint(info)
+	legacyFP, _ := Fingerprint(info, oldKey)
+	cases := []struct {
+		name   string
+		f      *Fingerprinter
+		info   Info
+		stored string
+		expect bool
+		err    error
+	}{
+		{name: "current", f: rotated, info: info, stored: fp, expect: true},
+		{name: "previous", f: rotated, info: info, stored: oldFP, expect: true},
+		{name: "legacy", f: rotated, info: info, stored: legacyFP, expect: true},
+		{name: "mismatch", f: rotated, info: other, stored: fp},
+		{name: "mismatch_previous", f: rotated, info: other, stored: oldFP},
+		{name: "unknown_key", f: old, info: info, stored: fp},
+		{name: "wrong_key_id", f: rotated, info: info, stored: "00000000:" + fp[9:]},
+		{name: "masked", f: rotated, info: masked, stored: fp, err: ErrMaskedPAN},
+		{name: "malformed", f: rotated, info: info, stored: "xyz", err: ErrFingerprintFormat},
+		{name: "short", f: rotated, info: info, stored: fp[:20], err: ErrFingerprintFormat},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := c.f.Matches(c.info, c.stored)
+			if err != c.err {
+				t.Log("expect (err):", c.err)
+				t.Log("actual (err):", err)
+				t.Fatal("unexpected error")
+			}
+			if actual != c.expect {
+				t.Log("expect:", c.expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import "strconv"
+
+// Justify denotes how a value is aligned in a fixed-width field
+type Justify int
+
+// Possible alignments
+const (
+	JustifyLeft  Justify = iota // value first, padded on the right
+	JustifyRight                // padded on the left, value last
+)
+
+// FixedWidthField describes where a PAN is in a fixed-width record
+type FixedWidthField struct {
+	Offset  int  // byte offset of the field, starts from 0
+	Length  int  // length of the field in bytes
+	Pad     byte // pad character, defaults to space if it is 0
+	Justify Justify
+}
+
+// ErrFixedWidthLine is returned by ParseFixedWidth if the line is too short
+type ErrFixedWidthLine struct {
+	End    int // byte offset where the field ends
+	Length int // actual length of the line
+}
+
+func (e ErrFixedWidthLine) Error() (ret string) {
+	return "creditcard: invalid argument: field ends at byte " +
+		strconv.Itoa(e.End) + " but line has only " + strconv.Itoa(e.Length) +
+		" bytes"
+}
+
+// ParseFixedWidth extracts the field f from line, and parses it by FromRaw
+//
+// Pad characters are trimmed from the side specified by f.Justify. If pad is a
+// digit, like zero, trimming stops when the PAN is 16 digits, as pad digits
+// cannot be told apart from PAN digits.
+func ParseFixedWidth(line []byte, f FixedWidthField) (ret Info, err error) {
+	return defaultConfig.ParseFixedWidth(line, f)
+}
+
+// ParseFixedWidth is same as package-level ParseFixedWidth, but uses c
+func (c *Config) ParseFixedWidth(line []byte, f FixedWidthField) (ret Info, err error) {
+	end := f.Offset + f.Length
+	if f.Offset < 0 || f.Length < 0 || end > len(line) {
+		err = ErrFixedWidthLine{End: end, Length: len(line)}
+		return
+	}
+
+	pad := f.Pad
+	if pad == 0 {
+		pad = ' '
+	}
+	keep := 0
+	if isDigit(pad) {
+		keep = 16
+	}
+
+	v := line[f.Offset:end]
+	if f.Justify == JustifyRight {
+		for len(v) > keep && v[0] == pad {
+			v = v[1:]
+		}
+	} else {
+		for len(v) > keep && v[len(v)-1] == pad {
+			v = v[:len(v)-1]
+		}
+	}
+
+	return c.FromRaw(string(v))
+}
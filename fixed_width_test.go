@@ -0,0 +1,48 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseFixedWidth(t *testing.T) {
+	right := FixedWidthField{Offset: 11, Length: 19, Justify: JustifyRight}
+	left := FixedWidthField{Offset: 11, Length: 19, Pad: '0'}
+	cases := []struct {
+		line   string
+		field  FixedWidthField
+		expect string
+		err    error
+	}{
+		{"00000000001   4111111111111111000012500", right, "4111111111111111", nil},
+		{"00000000001   3530111333300000000012500", right, "3530111333300000", nil},
+		{"000000000014111111111111111000000012500", left, "4111111111111111", nil},
+		{"000000000013530111333300000000000012500", left, "3530111333300000", nil},
+		{"00000000001411111111111111100000", left, "4111111111111111", nil},
+		{"00000000001        411111111111111000012500", right, "", ErrRaw},
+		{"00000000001   4111111111111", right, "", ErrFixedWidthLine{End: 30, Length: 27}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.line, func(t *testing.T) {
+			info, err := ParseFixedWidth([]byte(c.line), c.field)
+			if !errors.Is(err, c.err) {
+				t.Log("expect:", c.err)
+				t.Log("actual:", err)
+				t.Fatal("unexpected error")
+			}
+			if err != nil {
+				return
+			}
+			if actual := info.RawPAN(); actual != c.expect {
+				t.Log("expect:", c.expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+		})
+	}
+}
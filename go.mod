@@ -4,4 +4,4 @@
 
 module github.com/raohwork/creditcard
 
-go 1.13
+go 1.20
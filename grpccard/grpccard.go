@@ -0,0 +1,234 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+// Package grpccard provides gRPC server interceptors which scrub PAN-like
+// values in received messages and metadata
+//
+// It lives in its own module so the main package stays dependency-free.
+package grpccard
+
+import (
+	"context"
+	"strings"
+
+	"github.com/raohwork/creditcard"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Options configures the interceptors
+type Options struct {
+	// creditcard.GuardReject rejects the call with codes.InvalidArgument if
+	// a PAN is found, creditcard.GuardMask masks the PAN and passes the call
+	// to the handler
+	Mode creditcard.GuardMode
+	// calls to these methods are passed to the handler untouched, methods
+	// are full method names like "/pkg.Service/Method"
+	ExemptMethods []string
+	// tunes what is PAN-like, see creditcard.Redact
+	//
+	// Fields named by creditcard.ScrubSkipKeys are left alone, metadata keys
+	// included.
+	ScrubOptions []creditcard.ScrubOption
+}
+
+type interceptor struct {
+	mode   creditcard.GuardMode
+	exempt map[string]bool
+	s      *creditcard.Scrubber
+}
+
+func newInterceptor(opts Options) (ret *interceptor) {
+	ret = &interceptor{
+		mode:   opts.Mode,
+		exempt: map[string]bool{},
+		s:      creditcard.NewScrubber(opts.ScrubOptions...),
+	}
+	for _, m := range opts.ExemptMethods {
+		ret.exempt[m] = true
+	}
+	return
+}
+
+// errPAN is returned to clients in reject mode
+var errPAN = status.Error(codes.InvalidArgument, "raw pan is not allowed")
+
+// scrub masks PANs in str, returns number of changed strings
+func (i *interceptor) scrub(str string) (ret string, cnt int) {
+	if ret = i.s.Scrub(str); ret != str {
+		cnt = 1
+	}
+	return
+}
+
+// value processes v of field fd, it returns new value if changed
+func (i *interceptor) value(fd protoreflect.FieldDescriptor, v protoreflect.Value, apply bool) (ret protoreflect.Value, cnt int) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		str, n := i.scrub(v.String())
+		return protoreflect.ValueOfString(str), n
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return v, i.message(v.Message(), apply)
+	}
+	return v, 0
+}
+
+// message walks m and masks PANs in string fields if apply is true, returns
+// number of PAN-like strings
+func (i *interceptor) message(m protoreflect.Message, apply bool) (ret int) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if i.s.SkipKey(string(fd.Name())) {
+			return true
+		}
+		switch {
+		case fd.IsList():
+			l := v.List()
+			for idx := 0; idx < l.Len(); idx++ {
+				x, n := i.value(fd, l.Get(idx), apply)
+				if n > 0 && apply && fd.Kind() == protoreflect.StringKind {
+					l.Set(idx, x)
+				}
+				ret += n
+			}
+		case fd.IsMap():
+			mp := v.Map()
+			type change struct {
+				old protoreflect.MapKey
+				key protoreflect.MapKey
+				val protoreflect.Value
+			}
+			var changes []change
+			mp.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+				key, nk := k, 0
+				if fd.MapKey().Kind() == protoreflect.StringKind {
+					var str string
+					str, nk = i.scrub(k.String())
+					key = protoreflect.ValueOfString(str).MapKey()
+				}
+				val, nv := i.value(fd.MapValue(), v, apply)
+				ret += nk + nv
+				if nk > 0 || (nv > 0 && fd.MapValue().Kind() == protoreflect.StringKind) {
+					changes = append(changes, change{old: k, key: key, val: val})
+				}
+				return true
+			})
+			if apply {
+				for _, c := range changes {
+					mp.Clear(c.old)
+					mp.Set(c.key, c.val)
+				}
+			}
+		default:
+			x, n := i.value(fd, v, apply)
+			if n > 0 && apply && fd.Kind() == protoreflect.StringKind {
+				m.Set(fd, x)
+			}
+			ret += n
+		}
+		return true
+	})
+	return
+}
+
+// metadata masks PANs in incoming metadata of ctx if apply is true, returns
+// number of PAN-like values
+func (i *interceptor) metadata(ctx context.Context, apply bool) (ret context.Context, cnt int) {
+	ret = ctx
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return
+	}
+
+	var x metadata.MD
+	for k, arr := range md {
+		if strings.HasSuffix(k, "-bin") || i.s.SkipKey(k) {
+			continue
+		}
+		for idx, v := range arr {
+			str, n := i.scrub(v)
+			if n == 0 {
+				continue
+			}
+			cnt++
+			if x == nil {
+				x = md.Copy()
+			}
+			x[k][idx] = str
+		}
+	}
+	if apply && x != nil {
+		ret = metadata.NewIncomingContext(ctx, x)
+	}
+	return
+}
+
+// request processes ctx and req, returns status error in reject mode
+func (i *interceptor) request(ctx context.Context, req interface{}) (ret context.Context, err error) {
+	apply := i.mode != creditcard.GuardReject
+	ret, cnt := i.metadata(ctx, apply)
+	if m, ok := req.(proto.Message); ok && m != nil {
+		cnt += i.message(m.ProtoReflect(), apply)
+	}
+	if cnt > 0 && !apply {
+		err = errPAN
+	}
+	return
+}
+
+// UnaryServerInterceptor creates an interceptor which inspects string fields
+// of request message (nested ones included) and metadata for PAN-like values
+//
+// Depending on opts.Mode, the call is rejected with codes.InvalidArgument, or
+// the PANs are masked (see creditcard.Redact) before the call is passed to the
+// handler. Binary metadata (keys ending with "-bin") is not inspected.
+func UnaryServerInterceptor(opts Options) (ret grpc.UnaryServerInterceptor) {
+	i := newInterceptor(opts)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		if i.exempt[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		if ctx, err = i.request(ctx, req); err != nil {
+			return
+		}
+		return handler(ctx, req)
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	i   *interceptor
+}
+
+func (s *serverStream) Context() (ret context.Context) {
+	return s.ctx
+}
+
+func (s *serverStream) RecvMsg(m interface{}) (err error) {
+	if err = s.ServerStream.RecvMsg(m); err != nil {
+		return
+	}
+	_, err = s.i.request(context.Background(), m)
+	return
+}
+
+// StreamServerInterceptor is like UnaryServerInterceptor, but inspects every
+// message received from the stream and metadata of the stream
+func StreamServerInterceptor(opts Options) (ret grpc.StreamServerInterceptor) {
+	i := newInterceptor(opts)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		if i.exempt[info.FullMethod] {
+			return handler(srv, ss)
+		}
+		ctx, err := i.request(ss.Context(), nil)
+		if err != nil {
+			return
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx, i: i})
+	}
+}
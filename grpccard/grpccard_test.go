@@ -0,0 +1,309 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package grpccard
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/raohwork/creditcard"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// test.proto, built by hand so no generated code is needed:
+//
+//	message Card { string number = 1; }
+//	message Charge {
+//	  string pan = 1;
+//	  string note = 2;
+//	  repeated string tags = 3;
+//	  map<string, string> attrs = 4;
+//	  Card card = 5;
+//	  int64 amount = 6;
+//	  repeated Card history = 7;
+//	}
+func testTypes(t *testing.T) (charge, card protoreflect.MessageType) {
+	field := func(name string, num int32, typ descriptorpb.FieldDescriptorProto_Type, label descriptorpb.FieldDescriptorProto_Label, msg string) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{
+			Name:   proto.String(name),
+			Number: proto.Int32(num),
+			Type:   typ.Enum(),
+			Label:  label.Enum(),
+		}
+		if msg != "" {
+			f.TypeName = proto.String(msg)
+		}
+		return f
+	}
+	const (
+		str      = descriptorpb.FieldDescriptorProto_TYPE_STRING
+		message  = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+		optional = descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+		repeated = descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	)
+
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("test.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name:  proto.String("Card"),
+				Field: []*descriptorpb.FieldDescriptorProto{field("number", 1, str, optional, "")},
+			},
+			{
+				Name: proto.String("Charge"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("pan", 1, str, optional, ""),
+					field("note", 2, str, optional, ""),
+					field("tags", 3, str, repeated, ""),
+					field("attrs", 4, message, repeated, ".test.Charge.AttrsEntry"),
+					field("card", 5, message, optional, ".test.Card"),
+					field("amount", 6, descriptorpb.FieldDescriptorProto_TYPE_INT64, optional, ""),
+					field("history", 7, message, repeated, ".test.Card"),
+				},
+				NestedType: []*descriptorpb.DescriptorProto{{
+					Name: proto.String("AttrsEntry"),
+					Field: []*descriptorpb.FieldDescriptorProto{
+						field("key", 1, str, optional, ""),
+						field("value", 2, str, optional, ""),
+					},
+					Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+				}},
+			},
+		},
+	}
+	fd, err := protodesc.NewFile(fdp, nil)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	return dynamicpb.NewMessageType(fd.Messages().ByName("Charge")),
+		dynamicpb.NewMessageType(fd.Messages().ByName("Card"))
+}
+
+type chargeData struct {
+	pan, note string
+	tags      []string
+	attrs     map[string]string
+	card      string
+	history   []string
+}
+
+func newCharge(chargeType, cardType protoreflect.MessageType, d chargeData) (ret proto.Message) {
+	m := chargeType.New()
+	fields := m.Descriptor().Fields()
+	newCard := func(number string) protoreflect.Value {
+		c := cardType.New()
+		c.Set(c.Descriptor().Fields().ByName("number"), protoreflect.ValueOfString(number))
+		return protoreflect.ValueOfMessage(c)
+	}
+
+	m.Set(fields.ByName("pan"), protoreflect.ValueOfString(d.pan))
+	m.Set(fields.ByName("note"), protoreflect.ValueOfString(d.note))
+	m.Set(fields.ByName("amount"), protoreflect.ValueOfInt64(4111111111111111))
+	tags := m.Mutable(fields.ByName("tags")).List()
+	for _, x := range d.tags {
+		tags.Append(protoreflect.ValueOfString(x))
+	}
+	attrs := m.Mutable(fields.ByName("attrs")).Map()
+	for k, v := range d.attrs {
+		attrs.Set(protoreflect.ValueOfString(k).MapKey(), protoreflect.ValueOfString(v))
+	}
+	m.Set(fields.ByName("card"), newCard(d.card))
+	history := m.Mutable(fields.ByName("history")).List()
+	for _, x := range d.history {
+		history.Append(newCard(x))
+	}
+	return m.Interface()
+}
+
+func testCharges(t *testing.T) (input, masked proto.Message) {
+	chargeType, cardType := testTypes(t)
+	input = newCharge(chargeType, cardType, chargeData{
+		pan:     "4111111111111111",
+		note:    "4111111111111111",
+		tags:    []string{"vip", "card 5555 5555 5555 4444"},
+		attrs:   map[string]string{"4111111111111111": "x", "order": "1234567890123456", "raw": "4012888888881881"},
+		card:    "4111-1111-1111-1111",
+		history: []string{"1234", "5555555555554444"},
+	})
+	masked = newCharge(chargeType, cardType, chargeData{
+		pan:     "411111******1111",
+		note:    "4111111111111111",
+		tags:    []string{"vip", "card 5555 55** **** 4444"},
+		attrs:   map[string]string{"411111******1111": "x", "order": "1234567890123456", "raw": "401288******1881"},
+		card:    "4111-11**-****-1111",
+		history: []string{"1234", "555555******4444"},
+	})
+	return
+}
+
+func testContext() (ret context.Context) {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"x-card", "4111111111111111",
+		"x-request-id", "1234567890123456",
+		"x-trace-bin", "4111111111111111",
+	))
+}
+
+func TestUnaryMask(t *testing.T) {
+	input, expect := testCharges(t)
+	i := UnaryServerInterceptor(Options{
+		Mode:         creditcard.GuardMask,
+		ScrubOptions: []creditcard.ScrubOption{creditcard.ScrubSkipKeys("note")},
+	})
+
+	called := false
+	_, err := i(testContext(), input, &grpc.UnaryServerInfo{FullMethod: "/test.Pay/Charge"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		called = true
+		if !proto.Equal(req.(proto.Message), expect) {
+			t.Log("expect:", expect)
+			t.Log("actual:", req)
+			t.Fatal("unexpected message")
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		if v := md.Get("x-card"); v[0] != "411111******1111" {
+			t.Fatal("unexpected metadata:", v)
+		}
+		if v := md.Get("x-request-id"); v[0] != "1234567890123456" {
+			t.Fatal("unexpected metadata:", v)
+		}
+		if v := md.Get("x-trace-bin"); v[0] != "4111111111111111" {
+			t.Fatal("binary metadata should not be modified:", v)
+		}
+		return nil, nil
+	})
+	if err != nil || !called {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestUnaryReject(t *testing.T) {
+	input, _ := testCharges(t)
+	orig := proto.Clone(input)
+	i := UnaryServerInterceptor(Options{
+		Mode:          creditcard.GuardReject,
+		ExemptMethods: []string{"/test.Vault/Store"},
+		ScrubOptions:  []creditcard.ScrubOption{creditcard.ScrubSkipKeys("note")},
+	})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	_, err := i(context.Background(), input, &grpc.UnaryServerInfo{FullMethod: "/test.Pay/Charge"}, handler)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatal("unexpected error:", err)
+	}
+	if !proto.Equal(input, orig) {
+		t.Fatal("message should not be modified in reject mode")
+	}
+
+	resp, err := i(context.Background(), input, &grpc.UnaryServerInfo{FullMethod: "/test.Vault/Store"}, handler)
+	if err != nil || resp != "ok" {
+		t.Fatal("exempt method should pass:", err)
+	}
+
+	_, masked := testCharges(t)
+	if resp, err = i(context.Background(), masked, &grpc.UnaryServerInfo{FullMethod: "/test.Pay/Charge"}, handler); err != nil {
+		t.Fatal("masked message should pass:", err)
+	}
+
+	// PAN in metadata only
+	_, err = i(testContext(), masked, &grpc.UnaryServerInfo{FullMethod: "/test.Pay/Charge"}, handler)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+// fakeStream delivers msgs to RecvMsg
+type fakeStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	msgs []proto.Message
+}
+
+func (s *fakeStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeStream) RecvMsg(m interface{}) error {
+	if len(s.msgs) == 0 {
+		return io.EOF
+	}
+	proto.Merge(m.(proto.Message), s.msgs[0])
+	s.msgs = s.msgs[1:]
+	return nil
+}
+
+func TestStream(t *testing.T) {
+	input, expect := testCharges(t)
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Pay/Stream"}
+
+	mask := StreamServerInterceptor(Options{
+		Mode:         creditcard.GuardMask,
+		ScrubOptions: []creditcard.ScrubOption{creditcard.ScrubSkipKeys("note")},
+	})
+	err := mask(nil, &fakeStream{ctx: testContext(), msgs: []proto.Message{input, input}}, info, func(srv interface{}, ss grpc.ServerStream) error {
+		md, _ := metadata.FromIncomingContext(ss.Context())
+		if v := md.Get("x-card"); v[0] != "411111******1111" {
+			t.Fatal("unexpected metadata:", v)
+		}
+		cnt := 0
+		for {
+			m := expect.ProtoReflect().New().Interface()
+			if err := ss.RecvMsg(m); err == io.EOF {
+				break
+			} else if err != nil {
+				return err
+			}
+			if !proto.Equal(m, expect) {
+				t.Log("expect:", expect)
+				t.Log("actual:", m)
+				t.Fatal("unexpected message")
+			}
+			cnt++
+		}
+		if cnt != 2 {
+			t.Fatal("unexpected message count:", cnt)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	reject := StreamServerInterceptor(Options{
+		Mode:         creditcard.GuardReject,
+		ScrubOptions: []creditcard.ScrubOption{creditcard.ScrubSkipKeys("note")},
+	})
+	err = reject(nil, &fakeStream{ctx: context.Background(), msgs: []proto.Message{expect, input}}, info, func(srv interface{}, ss grpc.ServerStream) error {
+		m := expect.ProtoReflect().New().Interface()
+		if err := ss.RecvMsg(m); err != nil {
+			t.Fatal("unexpected error of masked message:", err)
+		}
+		m = expect.ProtoReflect().New().Interface()
+		return ss.RecvMsg(m)
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatal("unexpected error:", err)
+	}
+
+	err = reject(nil, &fakeStream{ctx: testContext()}, info, func(srv interface{}, ss grpc.ServerStream) error {
+		t.Fatal("handler should not be called")
+		return nil
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatal("unexpected error:", err)
+	}
+}
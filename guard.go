@@ -0,0 +1,230 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// GuardMode defines what PANGuard does when a PAN is found in request
+type GuardMode int
+
+// Supported guard modes
+const (
+	// GuardReject rejects the request with http status 400
+	GuardReject GuardMode = iota
+	// GuardMask masks the PAN and passes the request to next handler
+	GuardMask
+)
+
+// Error codes in the response body of rejected request
+const (
+	GuardCodePAN      = "raw_pan_not_allowed"
+	GuardCodeTooLarge = "request_body_too_large"
+	GuardCodeBadBody  = "malformed_request_body"
+)
+
+// DefaultGuardBodySize is the default value of GuardOptions.MaxBodySize
+const DefaultGuardBodySize = 1 << 20
+
+// GuardOptions configures PANGuard
+type GuardOptions struct {
+	Mode GuardMode
+	// requests with larger body are rejected with http status 413, default
+	// to DefaultGuardBodySize
+	MaxBodySize int64
+	// requests to these paths are passed to next handler untouched, path
+	// ending with "/" matches all paths under it
+	ExemptPaths []string
+	// tunes what is PAN-like, like ScrubRequireLuhn, see Redact
+	ScrubOptions []ScrubOption
+}
+
+func (o GuardOptions) exempt(p string) (ret bool) {
+	for _, x := range o.ExemptPaths {
+		if x == p || (strings.HasSuffix(x, "/") && strings.HasPrefix(p, x)) {
+			return true
+		}
+	}
+	return
+}
+
+func guardReject(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	io.WriteString(w, `{"error":"`+code+`"}`)
+}
+
+// redactQuery masks PANs in values of urlencoded s, returns number of PANs
+// found
+//
+// Only values containing PAN are re-encoded, other pairs are kept as is, so
+// order and encoding of s are preserved. Pairs cannot be decoded are skipped.
+func redactQuery(s string, m *panMatcher) (ret string, cnt int) {
+	pairs := strings.Split(s, "&")
+	for idx, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		v, err := url.QueryUnescape(value)
+		if err != nil {
+			continue
+		}
+		b := []byte(v)
+		if n := m.redact(b); n > 0 {
+			pairs[idx] = key + "=" + url.QueryEscape(string(b))
+			cnt += n
+		}
+	}
+	if cnt == 0 {
+		return s, 0
+	}
+	return strings.Join(pairs, "&"), cnt
+}
+
+// guardMultipart masks PANs in form fields of multipart body, files are not
+// inspected
+func guardMultipart(body []byte, boundary string, m *panMatcher) (ret []byte, cnt int, err error) {
+	r := multipart.NewReader(bytes.NewReader(body), boundary)
+	buf := &bytes.Buffer{}
+	w := multipart.NewWriter(buf)
+	if err = w.SetBoundary(boundary); err != nil {
+		return
+	}
+
+	for {
+		var part *multipart.Part
+		part, err = r.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return
+		}
+
+		var data []byte
+		if data, err = io.ReadAll(part); err != nil {
+			return
+		}
+		if part.FileName() == "" {
+			cnt += m.redact(data)
+		}
+
+		var pw io.Writer
+		if pw, err = w.CreatePart(part.Header); err != nil {
+			return
+		}
+		pw.Write(data)
+	}
+
+	err = w.Close()
+	ret = buf.Bytes()
+	return
+}
+
+// guardBody masks PANs in request body according to content type
+func guardBody(body []byte, contentType string, m *panMatcher) (ret []byte, cnt int, err error) {
+	typ, params, _ := mime.ParseMediaType(contentType)
+	switch {
+	case typ == "application/x-www-form-urlencoded":
+		if _, err = url.ParseQuery(string(body)); err != nil {
+			return
+		}
+		s, cnt := redactQuery(string(body), m)
+		return []byte(s), cnt, nil
+	case strings.HasPrefix(typ, "multipart/"):
+		return guardMultipart(body, params["boundary"], m)
+	case typ == "application/json" || strings.HasSuffix(typ, "+json"):
+		ret, cnt = redactJSON(body, nil, m)
+		return
+	}
+
+	ret = make([]byte, len(body))
+	copy(ret, body)
+	cnt = m.redact(ret)
+	return
+}
+
+// PANGuard is a http middleware which inspects query string and request body
+// for PAN-like sequences, see ScanPANs for what is PAN-like
+//
+// Depending on opts.Mode, the request is rejected with http status 400 and a
+// JSON body like {"error":"raw_pan_not_allowed"}, or the PANs are masked (see
+// Redact) before the request is passed to next. Request body is read into
+// memory (up to opts.MaxBodySize) and replaced by a replayable one.
+//
+// Body is inspected according to its Content-Type: values of urlencoded form,
+// form fields (but not files) of multipart form, strings and numbers in JSON
+// (masked numbers become strings), and raw bytes for others.
+func PANGuard(next http.Handler, opts GuardOptions) (ret http.Handler) {
+	if opts.MaxBodySize <= 0 {
+		opts.MaxBodySize = DefaultGuardBodySize
+	}
+	m := scrubMatcher(opts.ScrubOptions)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if opts.exempt(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if r.URL.RawQuery != "" {
+			if q, cnt := redactQuery(r.URL.RawQuery, m); cnt > 0 {
+				if opts.Mode == GuardReject {
+					guardReject(w, http.StatusBadRequest, GuardCodePAN)
+					return
+				}
+				r.URL.RawQuery = q
+				r.RequestURI = r.URL.RequestURI()
+			}
+		}
+
+		if r.Body == nil || r.Body == http.NoBody {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, opts.MaxBodySize+1))
+		r.Body.Close()
+		if err != nil {
+			guardReject(w, http.StatusBadRequest, GuardCodeBadBody)
+			return
+		}
+		if int64(len(body)) > opts.MaxBodySize {
+			guardReject(w, http.StatusRequestEntityTooLarge, GuardCodeTooLarge)
+			return
+		}
+
+		masked, cnt, err := guardBody(body, r.Header.Get("Content-Type"), m)
+		if err != nil {
+			guardReject(w, http.StatusBadRequest, GuardCodeBadBody)
+			return
+		}
+		if cnt > 0 {
+			if opts.Mode == GuardReject {
+				guardReject(w, http.StatusBadRequest, GuardCodePAN)
+				return
+			}
+			body = masked
+		}
+
+		r.ContentLength = int64(len(body))
+		r.Header.Set("Content-Length", strconv.Itoa(len(body)))
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,229 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type guardRecord struct {
+	called bool
+	query  string
+	body   string
+	replay string
+}
+
+func guardHandler(rec *guardRecord) (ret http.Handler) {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec.called = true
+		rec.query = r.URL.RawQuery
+		b, _ := io.ReadAll(r.Body)
+		rec.body = string(b)
+		if r.ContentLength != int64(len(b)) {
+			panic("incorrect content length")
+		}
+		rec.replay = rec.body
+		if r.GetBody != nil {
+			body, _ := r.GetBody()
+			b, _ = io.ReadAll(body)
+			rec.replay = string(b)
+		}
+	})
+}
+
+func multipartBody(t *testing.T, fields map[string]string, file string) (ret []byte, typ string) {
+	buf := &bytes.Buffer{}
+	w := multipart.NewWriter(buf)
+	w.SetBoundary("testboundary")
+	for k, v := range fields {
+		w.WriteField(k, v)
+	}
+	if file != "" {
+		f, _ := w.CreateFormFile("file", "a.txt")
+		f.Write([]byte(file))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	return buf.Bytes(), w.FormDataContentType()
+}
+
+func TestPANGuard(t *testing.T) {
+	mp, mpType := multipartBody(t, map[string]string{"pan": "4111111111111111"}, "")
+	mpMasked, _ := multipartBody(t, map[string]string{"pan": "411111******1111"}, "")
+	mpFile, mpFileType := multipartBody(t, map[string]string{"name": "x"}, "4111111111111111")
+
+	cases := []struct {
+		name   string
+		path   string
+		typ    string
+		body   string
+		expect string // masked body, empty if request should pass untouched
+		query  string // masked query
+	}{
+		{
+			name:   "json",
+			path:   "/pay",
+			typ:    "application/json; charset=utf-8",
+			body:   `{"card":{"number":"4111111111111111"},"order_id":"1234567890123456"}`,
+			expect: `{"card":{"number":"411111******1111"},"order_id":"1234567890123456"}`,
+		},
+		{
+			name:   "json_number",
+			path:   "/pay",
+			typ:    "application/json",
+			body:   `{"pan":4111111111111111}`,
+			expect: `{"pan":"411111******1111"}`,
+		},
+		{
+			name:   "urlencoded",
+			path:   "/pay",
+			typ:    "application/x-www-form-urlencoded",
+			body:   "amount=100&pan=4111+1111+1111+1111",
+			expect: "amount=100&pan=4111+11%2A%2A+%2A%2A%2A%2A+1111",
+		},
+		{
+			// other pairs are kept as is
+			name:   "urlencoded_order",
+			path:   "/pay",
+			typ:    "application/x-www-form-urlencoded",
+			body:   "z=%7e&pan=4111111111111111&a=1+2",
+			expect: "z=%7e&pan=411111%2A%2A%2A%2A%2A%2A1111&a=1+2",
+		},
+		{
+			name:   "multipart",
+			path:   "/pay",
+			typ:    mpType,
+			body:   string(mp),
+			expect: string(mpMasked),
+		},
+		{
+			name: "multipart_file",
+			path: "/pay",
+			typ:  mpFileType,
+			body: string(mpFile),
+		},
+		{
+			name:   "plain",
+			path:   "/pay",
+			typ:    "text/plain",
+			body:   "card 5555-5555-5555-4444",
+			expect: "card 5555-55**-****-4444",
+		},
+		{
+			name:  "query",
+			path:  "/pay?pan=4111111111111111&x=1",
+			query: "pan=411111%2A%2A%2A%2A%2A%2A1111&x=1",
+		},
+		{
+			name:  "query_order",
+			path:  "/pay?z=%7e&pan=4111111111111111&a&x=1+2",
+			query: "z=%7e&pan=411111%2A%2A%2A%2A%2A%2A1111&a&x=1+2",
+		},
+		{
+			name: "exempt",
+			path: "/vault/store",
+			typ:  "application/json",
+			body: `{"pan":"4111111111111111"}`,
+		},
+		{
+			name: "clean",
+			path: "/pay?z=%7e&x=1",
+			typ:  "application/json",
+			body: `{"order_id":"1234567890123456"}`,
+		},
+	}
+
+	opts := GuardOptions{ExemptPaths: []string{"/vault/"}}
+	modes := map[string]GuardMode{"reject": GuardReject, "mask": GuardMask}
+	for prefix, mode := range modes {
+		opts.Mode = mode
+		for _, c := range cases {
+			t.Run(prefix+"_"+c.name, func(t *testing.T) {
+				rec := &guardRecord{}
+				h := PANGuard(guardHandler(rec), opts)
+				req := httptest.NewRequest("POST", c.path, strings.NewReader(c.body))
+				req.Header.Set("Content-Type", c.typ)
+				resp := httptest.NewRecorder()
+				h.ServeHTTP(resp, req)
+
+				origQuery := ""
+				if i := strings.Index(c.path, "?"); i >= 0 {
+					origQuery = c.path[i+1:]
+				}
+				hit := c.expect != "" || c.query != ""
+				if mode == GuardReject && hit {
+					if rec.called {
+						t.Fatal("next handler should not be called")
+					}
+					if resp.Code != http.StatusBadRequest {
+						t.Fatal("unexpected status:", resp.Code)
+					}
+					if b := resp.Body.String(); b != `{"error":"raw_pan_not_allowed"}` {
+						t.Fatal("unexpected response:", b)
+					}
+					return
+				}
+
+				if !rec.called {
+					t.Fatal("next handler is not called")
+				}
+				expectBody, expectQuery := c.body, origQuery
+				if mode == GuardMask && c.expect != "" {
+					expectBody = c.expect
+				}
+				if mode == GuardMask && c.query != "" {
+					expectQuery = c.query
+				}
+				if rec.body != expectBody || rec.replay != expectBody {
+					t.Log("expect:", expectBody)
+					t.Log("actual:", rec.body)
+					t.Log("replay:", rec.replay)
+					t.Fatal("unexpected body")
+				}
+				if rec.query != expectQuery {
+					t.Log("expect:", expectQuery)
+					t.Log("actual:", rec.query)
+					t.Fatal("unexpected query")
+				}
+			})
+		}
+	}
+}
+
+func TestPANGuardBodySize(t *testing.T) {
+	rec := &guardRecord{}
+	h := PANGuard(guardHandler(rec), GuardOptions{Mode: GuardMask, MaxBodySize: 10})
+	req := httptest.NewRequest("POST", "/", strings.NewReader("12345678901"))
+	resp := httptest.NewRecorder()
+	h.ServeHTTP(resp, req)
+	if rec.called || resp.Code != http.StatusRequestEntityTooLarge {
+		t.Fatal("unexpected status:", resp.Code)
+	}
+}
+
+func TestPANGuardScrubOptions(t *testing.T) {
+	rec := &guardRecord{}
+	h := PANGuard(guardHandler(rec), GuardOptions{
+		Mode:         GuardMask,
+		ScrubOptions: []ScrubOption{ScrubAllowPrefix("9000"), ScrubRequireLuhn(false)},
+	})
+	req := httptest.NewRequest("POST", "/pay", strings.NewReader("ids 9000123456789016 4111111111111112"))
+	req.Header.Set("Content-Type", "text/plain")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	expect := "ids 9000123456789016 411111******1112"
+	if rec.body != expect {
+		t.Log("expect:", expect)
+		t.Log("actual:", rec.body)
+		t.Fatal("unexpected body")
+	}
+}
@@ -0,0 +1,168 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// HashAlgo is hash algorithm used by HashedList
+type HashAlgo int
+
+// Supported hash algorithms
+const (
+	HashSHA256 HashAlgo = iota
+	HashSHA1
+	HashSHA512
+)
+
+// ErrHashAlgo is returned by LoadHashedList if the algorithm is not supported
+const ErrHashAlgo ErrArgument = "unsupported hash algorithm"
+
+func (a HashAlgo) new() (ret hash.Hash) {
+	switch a {
+	case HashSHA256:
+		return sha256.New()
+	case HashSHA1:
+		return sha1.New()
+	case HashSHA512:
+		return sha512.New()
+	}
+	return nil
+}
+
+// ErrHashedListLine is returned by LoadHashedList if a line is malformed
+type ErrHashedListLine struct {
+	Line int // line number, starts from 1
+}
+
+func (e ErrHashedListLine) Error() (ret string) {
+	return "creditcard: invalid argument: malformed hash at line " +
+		strconv.Itoa(e.Line)
+}
+
+// HashedList is a list of hashed PANs, like blocklists from issuers
+//
+// Hashes are stored in a sorted flat slice, so millions of entries cost little
+// more than the hashes themselves. It is safe for concurrent use.
+type HashedList struct {
+	algo   HashAlgo
+	salt   []byte
+	size   int    // size of a hash
+	hashes []byte // sorted hashes
+}
+
+// LoadHashedList reads hex-encoded hashes, one per line, from r
+//
+// Each hash is computed by algo over salt followed by canonical PAN, like
+// SHA256(salt + "4111111111111111"). Upper-case hex and surrounding spaces are
+// accepted, empty lines are skipped. It returns ErrHashedListLine if a line is
+// not a valid hash.
+func LoadHashedList(r io.Reader, algo HashAlgo, salt []byte) (ret *HashedList, err error) {
+	h := algo.new()
+	if h == nil {
+		return nil, ErrHashAlgo
+	}
+	l := &HashedList{
+		algo: algo,
+		salt: append([]byte(nil), salt...),
+		size: h.Size(),
+	}
+
+	s := bufio.NewScanner(r)
+	buf := make([]byte, l.size)
+	for line := 1; s.Scan(); line++ {
+		str := strings.TrimSpace(s.Text())
+		if str == "" {
+			continue
+		}
+		if len(str) != l.size*2 {
+			return nil, ErrHashedListLine{Line: line}
+		}
+		if _, err = hex.Decode(buf, []byte(str)); err != nil {
+			return nil, ErrHashedListLine{Line: line}
+		}
+		l.hashes = append(l.hashes, buf...)
+	}
+	if err = s.Err(); err != nil {
+		return
+	}
+
+	sort.Sort(hashSorter{l})
+	l.dedupe()
+	return l, nil
+}
+
+// hashSorter sorts hashes of a HashedList in place
+type hashSorter struct{ l *HashedList }
+
+func (s hashSorter) Len() int { return s.l.Len() }
+func (s hashSorter) Less(i, j int) bool {
+	return bytes.Compare(s.l.at(i), s.l.at(j)) < 0
+}
+func (s hashSorter) Swap(i, j int) {
+	a, b := s.l.at(i), s.l.at(j)
+	for idx := range a {
+		a[idx], b[idx] = b[idx], a[idx]
+	}
+}
+
+func (l *HashedList) at(idx int) (ret []byte) {
+	return l.hashes[idx*l.size : (idx+1)*l.size]
+}
+
+func (l *HashedList) dedupe() {
+	n := l.Len()
+	if n == 0 {
+		return
+	}
+	cnt := 1
+	for idx := 1; idx < n; idx++ {
+		if bytes.Equal(l.at(idx), l.at(cnt-1)) {
+			continue
+		}
+		copy(l.at(cnt), l.at(idx))
+		cnt++
+	}
+	l.hashes = l.hashes[:cnt*l.size]
+}
+
+// Len returns number of distinct hashes
+func (l *HashedList) Len() (ret int) {
+	return len(l.hashes) / l.size
+}
+
+// Contains reports if hash of info is in the list
+//
+// It returns ErrMaskedPAN if info is masked, or ErrNoCard if info is NoCard.
+func (l *HashedList) Contains(info Info) (ret bool, err error) {
+	if info == nil || info.IsZero() {
+		return false, ErrNoCard
+	}
+	pan := info.Canonical()
+	if strings.Contains(pan, "*") {
+		return false, ErrMaskedPAN
+	}
+
+	h := l.algo.new()
+	h.Write(l.salt)
+	h.Write([]byte(pan))
+	sum := h.Sum(nil)
+
+	idx := sort.Search(l.Len(), func(i int) bool {
+		return bytes.Compare(l.at(i), sum) >= 0
+	})
+	return idx < l.Len() && bytes.Equal(l.at(idx), sum), nil
+}
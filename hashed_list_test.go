@@ -0,0 +1,94 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"strings"
+	"testing"
+)
+
+// sha256("salt" + pan) of 4111111111111111 and 5555555555554444
+const hashedListFixture = `
+523460d6547c513625f19e4738408d9d516c59376f7e2ba940e25d874b41f8bd
+  1062C4FD70F7965AF3F83001DA89422193110A5D93ADBDAA0CD1BFF8370687A0
+523460d6547c513625f19e4738408d9d516c59376f7e2ba940e25d874b41f8bd
+0000000000000000000000000000000000000000000000000000000000000000
+`
+
+func TestHashedList(t *testing.T) {
+	l, err := LoadHashedList(strings.NewReader(hashedListFixture), HashSHA256, []byte("salt"))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if l.Len() != 3 {
+		t.Fatal("unexpected length:", l.Len())
+	}
+
+	cases := []struct {
+		pan    string
+		expect bool
+		err    error
+	}{
+		{"4111111111111111", true, nil},
+		{"5555555555554444", true, nil},
+		{"4111111111000066", false, nil},
+		{"411111******1111", false, ErrMaskedPAN},
+	}
+
+	for _, c := range cases {
+		t.Run(c.pan, func(t *testing.T) {
+			info, _ := FromRaw(c.pan)
+			actual, err := l.Contains(info)
+			if err != c.err {
+				t.Log("expect (err):", c.err)
+				t.Log("actual (err):", err)
+				t.Fatal("unexpected error")
+			}
+			if actual != c.expect {
+				t.Log("expect:", c.expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+		})
+	}
+
+	if _, err := l.Contains(NoCard); err != ErrNoCard {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestHashedListSHA1(t *testing.T) {
+	l, err := LoadHashedList(strings.NewReader("9774c4d698db513c875443b99df1a93fb03db726\n"), HashSHA1, []byte("salt"))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	info, _ := FromRaw("4111111111111111")
+	if ok, _ := l.Contains(info); !ok {
+		t.Fatal("expected hit")
+	}
+}
+
+func TestHashedListError(t *testing.T) {
+	cases := map[string]error{
+		"523460d6547c513625f19e4738408d9d516c59376f7e2ba940e25d874b41f8bd\n\nxyz\n": ErrHashedListLine{Line: 3},
+		"523460d6547c513625f19e4738408d9d516c59376f7e2ba940e25d874b41f8b\n":         ErrHashedListLine{Line: 1},
+		"\n523460d6547c513625f19e4738408d9d516c59376f7e2ba940e25d874b41f8bg\n":      ErrHashedListLine{Line: 2},
+	}
+
+	for input, expect := range cases {
+		t.Run(expect.Error(), func(t *testing.T) {
+			_, err := LoadHashedList(strings.NewReader(input), HashSHA256, nil)
+			if err != expect {
+				t.Log("expect:", expect)
+				t.Log("actual:", err)
+				t.Fatal("unexpected error")
+			}
+		})
+	}
+
+	if _, err := LoadHashedList(strings.NewReader(""), HashAlgo(-1), nil); err != ErrHashAlgo {
+		t.Fatal("unexpected error:", err)
+	}
+}
@@ -0,0 +1,42 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+// Hints describes how a frontend should render PAN and CVC input of a brand
+//
+// It can be marshaled to JSON and shipped to the browser directly.
+type Hints struct {
+	// positions to insert a gap when displaying digits, like [4, 8, 12]
+	Gaps []int `json:"gaps"`
+	// max length of the PAN
+	MaxLength int `json:"max_length"`
+	// name of card security code printed on the card, like "CVV" or "CID"
+	CVCName string `json:"cvc_name"`
+	// length of card security code, 0 if there's none like UATP
+	CVCLength int `json:"cvc_length"`
+}
+
+// FrontendHints returns Hints of t
+//
+// Unknown card types get gaps every 4 digits, max length 19 and 3-digit
+// "CVC".
+func FrontendHints(t CardType) (ret Hints) {
+	b, ok := brands[t]
+	if !ok {
+		return Hints{
+			Gaps:      []int{4, 8, 12, 16},
+			MaxLength: 19,
+			CVCName:   "CVC",
+			CVCLength: 3,
+		}
+	}
+
+	return Hints{
+		Gaps:      append([]int(nil), b.gaps...),
+		MaxLength: b.lengths[len(b.lengths)-1],
+		CVCName:   b.cvcName,
+		CVCLength: b.cvcLength,
+	}
+}
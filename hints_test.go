@@ -0,0 +1,57 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFrontendHints(t *testing.T) {
+	cases := []struct {
+		name   string
+		typ    CardType
+		expect string
+	}{
+		{"amex", AmericanExpress, `{"gaps":[4,10],"max_length":15,"cvc_name":"CID","cvc_length":4}`},
+		{"uatp", UATPCard, `{"gaps":[4,9],"max_length":15,"cvc_name":"","cvc_length":0}`},
+		{"visa", VISACard, `{"gaps":[4,8,12],"max_length":19,"cvc_name":"CVV","cvc_length":3}`},
+		{"unknown", UnknownCardType, `{"gaps":[4,8,12,16],"max_length":19,"cvc_name":"CVC","cvc_length":3}`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			buf, err := json.Marshal(FrontendHints(c.typ))
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if actual := string(buf); actual != c.expect {
+				t.Log("expect:", c.expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+		})
+	}
+}
+
+func TestFrontendHintsConsistency(t *testing.T) {
+	for typ, b := range brands {
+		lengths := b.lengths
+		h := FrontendHints(typ)
+		if h.MaxLength != lengths[len(lengths)-1] {
+			t.Fatal("unexpected max length of", typ, h.MaxLength)
+		}
+		// UATP has no security code
+		if (h.CVCName == "") != (h.CVCLength == 0) || len(h.Gaps) == 0 {
+			t.Fatal("missing hints of", typ)
+		}
+	}
+
+	h := FrontendHints(VISACard)
+	h.Gaps[0] = 0
+	if FrontendHints(VISACard).Gaps[0] != 4 {
+		t.Fatal("gaps are shared between calls")
+	}
+}
@@ -0,0 +1,140 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"strconv"
+	"unicode"
+)
+
+// MaxHolderNameLength is the maximum length of cardholder name in track 1
+const MaxHolderNameLength = 26
+
+// ErrHolderName indicates there's something wrong with cardholder name
+type ErrHolderName string
+
+func (e ErrHolderName) Error() (ret string) {
+	return "creditcard: incorrect holder name: " + string(e)
+}
+
+// Possible errors returned by holder name helpers
+const (
+	ErrHolderNameEmpty  ErrHolderName = "holder name cannot be empty"
+	ErrHolderNameLength ErrHolderName = "holder name must be at most 26 characters"
+)
+
+// ErrHolderNameRune is returned if a rune in holder name cannot be represented
+// in track 1 character set
+type ErrHolderNameRune struct {
+	Rune   rune // the rune which is not representable
+	Offset int  // byte offset of the rune in original string
+}
+
+func (e ErrHolderNameRune) Error() (ret string) {
+	return "creditcard: incorrect holder name: unrepresentable character " +
+		strconv.QuoteRune(e.Rune) + " at offset " + strconv.Itoa(e.Offset)
+}
+
+// common latin letters with diacritics, mapped to uppercased ascii
+var holderNameTranslit = map[rune]string{
+	'À': "A", 'Á': "A", 'Â': "A", 'Ã': "A", 'Ä': "A", 'Å': "A", 'Ā': "A", 'Ă': "A", 'Ą': "A",
+	'Æ': "AE",
+	'Ç': "C", 'Ć': "C", 'Ĉ': "C", 'Ċ': "C", 'Č': "C",
+	'Ď': "D", 'Đ': "D", 'Ð': "D",
+	'È': "E", 'É': "E", 'Ê': "E", 'Ë': "E", 'Ē': "E", 'Ĕ': "E", 'Ė': "E", 'Ę': "E", 'Ě': "E",
+	'Ĝ': "G", 'Ğ': "G", 'Ġ': "G", 'Ģ': "G",
+	'Ĥ': "H", 'Ħ': "H",
+	'Ì': "I", 'Í': "I", 'Î': "I", 'Ï': "I", 'Ĩ': "I", 'Ī': "I", 'Ĭ': "I", 'Į': "I", 'İ': "I", 'ı': "I",
+	'Ĵ': "J",
+	'Ķ': "K",
+	'Ĺ': "L", 'Ļ': "L", 'Ľ': "L", 'Ŀ': "L", 'Ł': "L",
+	'Ñ': "N", 'Ń': "N", 'Ņ': "N", 'Ň': "N",
+	'Ò': "O", 'Ó': "O", 'Ô': "O", 'Õ': "O", 'Ö': "O", 'Ø': "O", 'Ō': "O", 'Ŏ': "O", 'Ő': "O",
+	'Œ': "OE",
+	'Ŕ': "R", 'Ŗ': "R", 'Ř': "R",
+	'Ś': "S", 'Ŝ': "S", 'Ş': "S", 'Š': "S", 'Ș': "S",
+	'ß': "SS",
+	'Ţ': "T", 'Ť': "T", 'Ŧ': "T", 'Ț': "T",
+	'Þ': "TH",
+	'Ù': "U", 'Ú': "U", 'Û': "U", 'Ü': "U", 'Ũ': "U", 'Ū': "U", 'Ŭ': "U", 'Ů': "U", 'Ű': "U", 'Ų': "U",
+	'Ŵ': "W",
+	'Ý': "Y", 'Ÿ': "Y", 'Ŷ': "Y",
+	'Ź': "Z", 'Ż': "Z", 'Ž': "Z",
+}
+
+func isHolderNameRune(r rune) (ret bool) {
+	switch {
+	case r >= 'A' && r <= 'Z':
+		return true
+	case r == ' ', r == '/', r == '\'', r == '.', r == '-':
+		return true
+	}
+
+	return false
+}
+
+// ValidateHolderName checks if s can be used as cardholder name as-is
+//
+// A valid name is composed by A-Z, space, slash, apostrophe, dot and hyphen,
+// and is at most MaxHolderNameLength characters. Use NormalizeHolderName to
+// convert user input into valid form.
+func ValidateHolderName(s string) (err error) {
+	if s == "" {
+		return ErrHolderNameEmpty
+	}
+
+	for idx, r := range s {
+		if !isHolderNameRune(r) {
+			return ErrHolderNameRune{Rune: r, Offset: idx}
+		}
+	}
+
+	if len(s) > MaxHolderNameLength {
+		return ErrHolderNameLength
+	}
+
+	return
+}
+
+// NormalizeHolderName converts s into a valid cardholder name
+//
+// It transliterates common latin letters with diacritics ("Müller" becomes
+// "MULLER"), converts to upper case, collapses consecutive white spaces into
+// single space and trims leading/trailing spaces. An ErrHolderNameRune is
+// returned if some rune cannot be represented, and ErrHolderNameLength if the
+// result is longer than MaxHolderNameLength.
+func NormalizeHolderName(s string) (ret string, err error) {
+	buf := make([]byte, 0, len(s))
+	space := false
+	for idx, r := range s {
+		if unicode.IsSpace(r) {
+			space = len(buf) > 0
+			continue
+		}
+
+		r = unicode.ToUpper(r)
+		str := string(r)
+		if !isHolderNameRune(r) {
+			x, ok := holderNameTranslit[r]
+			if !ok {
+				err = ErrHolderNameRune{Rune: r, Offset: idx}
+				return
+			}
+			str = x
+		}
+
+		if space {
+			buf = append(buf, ' ')
+			space = false
+		}
+		buf = append(buf, str...)
+	}
+
+	ret = string(buf)
+	if err = ValidateHolderName(ret); err != nil {
+		ret = ""
+	}
+	return
+}
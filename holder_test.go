@@ -0,0 +1,97 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeHolderName(t *testing.T) {
+	cases := []struct {
+		name   string
+		input  string
+		expect string
+		err    error
+	}{
+		{name: "ascii", input: "john doe", expect: "JOHN DOE"},
+		{name: "umlaut", input: "Jürgen Müller", expect: "JURGEN MULLER"},
+		{name: "eszett", input: "Strauß", expect: "STRAUSS"},
+		{name: "ligature", input: "Æsa Œuvre", expect: "AESA OEUVRE"},
+		{name: "accents", input: "José Ñúñez-Çelik", expect: "JOSE NUNEZ-CELIK"},
+		{name: "polish", input: "Łukasz Żółć", expect: "LUKASZ ZOLC"},
+		{name: "punct", input: "o'brien/j. mr", expect: "O'BRIEN/J. MR"},
+		{name: "spaces", input: "  john \t\n  doe  ", expect: "JOHN DOE"},
+		{
+			name:  "cjk",
+			input: "王小明",
+			err:   ErrHolderNameRune{Rune: '王', Offset: 0},
+		},
+		{
+			name:  "mixed_cjk",
+			input: "MING 王",
+			err:   ErrHolderNameRune{Rune: '王', Offset: 5},
+		},
+		{
+			name:  "digit",
+			input: "R2D2",
+			err:   ErrHolderNameRune{Rune: '2', Offset: 1},
+		},
+		{name: "empty", input: "   ", err: ErrHolderNameEmpty},
+		{
+			name:   "max_length",
+			input:  strings.Repeat("ä", MaxHolderNameLength),
+			expect: strings.Repeat("A", MaxHolderNameLength),
+		},
+		{
+			name:  "too_long",
+			input: strings.Repeat("a", MaxHolderNameLength+1),
+			err:   ErrHolderNameLength,
+		},
+		{
+			name:  "too_long_after_translit",
+			input: strings.Repeat("a", MaxHolderNameLength-1) + "ß",
+			err:   ErrHolderNameLength,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := NormalizeHolderName(c.input)
+			if err != c.err {
+				t.Log("expect (err):", c.err)
+				t.Log("actual (err):", err)
+				t.Fatal("unexpected error")
+			}
+			if actual != c.expect {
+				t.Log("expect:", c.expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+		})
+	}
+}
+
+func TestValidateHolderName(t *testing.T) {
+	cases := map[string]error{
+		"JOHN DOE":                               nil,
+		"O'BRIEN/J. MR":                          nil,
+		"john doe":                               ErrHolderNameRune{Rune: 'j', Offset: 0},
+		"MÜLLER":                                 ErrHolderNameRune{Rune: 'Ü', Offset: 1},
+		"":                                       ErrHolderNameEmpty,
+		strings.Repeat("A", MaxHolderNameLength): nil,
+		strings.Repeat("A", MaxHolderNameLength+1): ErrHolderNameLength,
+	}
+
+	for name, expect := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := ValidateHolderName(name); err != expect {
+				t.Log("expect:", expect)
+				t.Log("actual:", err)
+				t.Fatal("unexpected result")
+			}
+		})
+	}
+}
@@ -5,119 +5,191 @@
 package creditcard
 
 import (
-	"regexp"
+	"sort"
 	"strings"
+	"time"
 )
 
-var (
-	reVISA            *regexp.Regexp
-	reMaster          *regexp.Regexp
-	reAmericanExpress *regexp.Regexp
-	reJCB             *regexp.Regexp
-	reUnionPay        *regexp.Regexp
-)
+// card types are detected by at most this many leading digits, which is the
+// length of an 8-digit IIN
+const maxPrefixDigits = 8
 
-func init() {
-	// 4xxx
-	reVISA = regexp.MustCompile("^4")
-	// 51-55, 2221-2720
-	reMaster = regexp.MustCompile("^(5[1-5]|222[1-9]|22[3-9][0-9]|27[01][0-9]|2720)")
-	// 34, 37
-	reAmericanExpress = regexp.MustCompile("^3[47]")
-	// 3528-3589
-	reJCB = regexp.MustCompile("^35(2[89]|[3-8][0-9])")
-	reUnionPay = regexp.MustCompile("^(62|81)")
+// prefixValue parses first n bytes of s as a number, ok is false if any of
+// them is not a digit
+func prefixValue(s string, n int) (ret int, ok bool) {
+	if len(s) < n {
+		return
+	}
+	for idx := 0; idx < n; idx++ {
+		if !isDigit(s[idx]) {
+			return 0, false
+		}
+		ret = ret*10 + int(s[idx]-'0')
+	}
+	return ret, true
 }
 
-func cardType(pan [4]string) (ret CardType) {
-	switch {
-	case reVISA.MatchString(pan[0]):
-		return VISACard
-	case reMaster.MatchString(pan[0]):
-		return MasterCard
-	case reAmericanExpress.MatchString(pan[0]):
-		return AmericanExpress
-	case reJCB.MatchString(pan[0]):
-		return JCBCard
-	case reUnionPay.MatchString(pan[0]):
-		return UnionPay
+// matchedCardType detects card type of pan with builtin ranges and matchers
+// of c
+func (c *Config) matchedCardType(pan string) (ret CardType) {
+	list := c.currentMatchers()
+	if len(list) == 0 {
+		return builtinCardType(pan)
 	}
 
-	return UnknownCardType
+	// see RegisterMatcher
+	idx := sort.Search(len(list), func(i int) bool { return list[i].priority <= 0 })
+	prefix := knownPrefix(pan)
+	if t, ok := runMatchers(list[:idx], prefix); ok {
+		return t
+	}
+	if ret = builtinCardType(pan); ret != UnknownCardType {
+		return
+	}
+	ret, _ = runMatchers(list[idx:], prefix)
+	return
 }
 
-func (i *info) Validate() (err error) {
-	pan := i.RawPAN()
-	if strings.Index(pan, "*") != -1 {
-		return ErrValidateMasked
-	}
-
-	l := len(pan)
-	checksum := pan[l-1] - '0'
-	sum := byte(0)
-	for idx, c := range []byte(pan[:l-1]) {
-		x := byte(2)
-		if idx%2 == 0 {
-			x = byte(1)
+// builtinCardType matches known leading digits of pan, up to maxPrefixDigits,
+// against prefixRanges
+func builtinCardType(pan string) (ret CardType) {
+	prefix := knownPrefix(pan)
+	if len(prefix) > maxPrefixDigits {
+		prefix = prefix[:maxPrefixDigits]
+	}
+
+	ret = UnknownCardType
+	digits := 0
+	for _, r := range prefixRanges {
+		if r.digits <= digits {
+			continue
 		}
-		c -= '0'
-		c *= x
-		if c > 9 {
-			c -= 9
+		if v, ok := prefixValue(prefix, r.digits); ok && v >= r.lo && v <= r.hi {
+			ret, digits = r.typ, r.digits
 		}
-		sum += c
-	}
-	if m := (checksum + sum) % 10; m != 0 {
-		return ErrValidate
 	}
 
 	return
 }
 
+func (i *info) Validate() (err error) {
+	o := i.config().currentObserver()
+	if o == nil {
+		return i.validate()
+	}
+	start := time.Now()
+	err = i.validate()
+	o.OnValidate(i.CardType(), err, time.Since(start))
+	return
+}
+
+func (i *info) validate() (err error) {
+	return validateChecksum(i, i.config().checksumRule(i.CardType()))
+}
+
+func (i *info) IsZero() (ret bool) {
+	return i == nil || i.pan == ""
+}
+
+func (i *info) IsFullyMasked() (ret bool) {
+	if i.IsZero() {
+		return
+	}
+	for idx := 0; idx < len(i.pan); idx++ {
+		if i.pan[idx] != '*' {
+			return false
+		}
+	}
+	return true
+}
+
 func (i *info) CardType() (ret CardType) {
+	if i.IsZero() {
+		return UnknownCardType
+	}
+	if i.typ == pendingCardType {
+		return i.config().cardType(i.pan)
+	}
 	return i.typ
 }
 
 func (i *info) Checksum() (ret string) {
-	return i.pan[3][3:]
+	if i.IsZero() {
+		return
+	}
+	return i.pan[len(i.pan)-1:]
 }
 
 func (i *info) Last4() (ret string) {
-	return i.pan[3]
+	if i.IsZero() {
+		return
+	}
+	return i.pan[len(i.pan)-4:]
 }
 
 func (i *info) First6() (ret string) {
-	return i.pan[0] + i.pan[1][:2]
+	if i.IsZero() {
+		return
+	}
+	return i.pan[:6]
 }
 
 func (i *info) FullLast4() (ret string) {
-	return "****-****-****-" + i.pan[3]
+	if i.IsZero() {
+		return
+	}
+	return i.format(strings.Repeat("*", len(i.pan)-4) + i.Last4())
 }
 
 func (i *info) FullFirst6() (ret string) {
-	return i.pan[0] + "-" + i.pan[1][:2] + "**-****-****"
+	if i.IsZero() {
+		return
+	}
+	return i.format(i.First6() + strings.Repeat("*", len(i.pan)-6))
 }
 
 func (i *info) RawMasked() (ret string) {
-	return i.First6() + "******" + i.Last4()
+	if i.IsZero() {
+		return
+	}
+	return i.First6() + strings.Repeat("*", len(i.pan)-10) + i.Last4()
 }
 
 func (i *info) Masked() (ret string) {
-	return i.pan[0] + "-" + i.pan[1][:2] + "**-****-" + i.pan[3]
+	if i.IsZero() {
+		return
+	}
+	return i.format(i.RawMasked())
+}
+
+// String returns the masked pan, so printing an Info with %v or %s never leaks
+// the full number. Use RawPAN or PAN to get it explicitly
+func (i *info) String() (ret string) {
+	return i.Masked()
+}
+
+// GoString returns the masked pan for %#v
+func (i *info) GoString() (ret string) {
+	return "creditcard.Info(" + i.Masked() + ")"
 }
 
 func (i *info) RawPAN() (ret string) {
-	return strings.Join(i.pan[:], "")
+	if i.IsZero() {
+		return
+	}
+	return i.pan
 }
 
 func (i *info) PAN() (ret string) {
-	return strings.Join(i.pan[:], "-")
+	if i.IsZero() {
+		return
+	}
+	return i.format(i.pan)
 }
 
-var reSlicedPAN *regexp.Regexp
-
-func init() {
-	reSlicedPAN = regexp.MustCompile("^[0-9*]{0,4}$")
+// isSection reports if v is a valid element of FromSlice
+func isSection(v string) (ret bool) {
+	return len(v) <= 4 && isPANChars(v)
 }
 
 // FromSlice creates Info instance from slice of string
@@ -129,8 +201,58 @@ func init() {
 //   - each elemment is composed by digits of asterisk (/[0-9*]/)
 //
 // Missing digits are padded by asterisks ("*"). For example,
-// FromSlice(nil).PAN() == "****-****-****-****"
+// FromSlice(nil).PAN() == "****-****-****-****". Padding can be disabled by
+// WithNoPadding.
+//
+// Groups of 14- and 15-digit PANs are accepted if they match the layout
+// exactly, 4-6-4 like Diners Club and 4-6-5 like AMEX. They are never padded.
+//
+// Other lengths (12, 13, 17, 18 and 19 digits) are accepted only if
+// WithVariableLength is used, grouped as 4-4-4, 4-4-5, 4-4-4-5, 4-4-4-6 and
+// 4-4-4-4-3, and 19-digit PANs may also be grouped as 6-13 like UnionPay. Since
+// they are matched before padding, "4111-1111-1111" is a 12-digit PAN instead
+// of a padded 16-digit one in such Config.
+//
+// PAN, Masked and other grouped outputs keep the grouping of the input.
 func FromSlice(arr []string) (ret Info, err error) {
+	return defaultConfig.FromSlice(arr)
+}
+
+// FromSlice is same as package-level FromSlice, but uses c
+func (c *Config) FromSlice(arr []string) (ret Info, err error) {
+	o := c.currentObserver()
+	if o == nil {
+		return c.fromSlice(arr)
+	}
+	start := time.Now()
+	ret, err = c.fromSlice(arr)
+	o.OnParse(err, time.Since(start))
+	return
+}
+
+func (c *Config) fromSlice(arr []string) (ret Info, err error) {
+	return c.fromGroups(arr, c.lengths())
+}
+
+// fromGroups is fromSlice, but accepts exact layouts of lengths
+func (c *Config) fromGroups(arr []string, lengths []int) (ret Info, err error) {
+	corrections := 0
+	for idx, v := range arr {
+		v, n := c.normalize(v)
+		arr[idx] = v
+		corrections += n
+	}
+	if pan, layout, ok := joinLayout(arr, lengths); ok {
+		ret = &info{
+			pan:         pan,
+			typ:         c.cardType(pan),
+			cfg:         c,
+			layout:      layout,
+			corrections: corrections,
+		}
+		return
+	}
+
 	l := len(arr)
 	if l > 4 {
 		err = ErrSection
@@ -143,18 +265,22 @@ func FromSlice(arr []string) (ret Info, err error) {
 	}
 
 	for idx, v := range arr {
-		if !reSlicedPAN.MatchString(v) {
+		if !isSection(v) {
 			err = ErrSection
 			return
 		}
 		if l := len(v); l < 4 {
+			if c.noPadding {
+				err = ErrSectionValue{Index: idx, Width: 4}
+				return
+			}
 			arr[idx] = v + strings.Repeat("*", 4-l)
 		}
 	}
 
-	pan := [4]string{arr[0], arr[1], arr[2], arr[3]}
-	typ := cardType(pan)
-	ret = &info{pan: pan, typ: typ}
+	pan := strings.Join(arr, "")
+	typ := c.cardType(pan)
+	ret = &info{pan: pan, typ: typ, cfg: c, corrections: corrections}
 	return
 }
 
@@ -163,30 +289,62 @@ func FromSlice(arr []string) (ret Info, err error) {
 // It's nothing but FromSlice(strings.Split(pan, "-")), so everything about
 // FromSlice applies to it.
 func FromDashed(str string) (ret Info, err error) {
-	arr := strings.Split(str, "-")
-	return FromSlice(arr)
+	return defaultConfig.FromDashed(str)
+}
+
+// FromDashed is same as package-level FromDashed, but uses c
+func (c *Config) FromDashed(str string) (ret Info, err error) {
+	o := c.currentObserver()
+	if o == nil {
+		return c.fromSlice(strings.Split(str, "-"))
+	}
+	start := time.Now()
+	ret, err = c.fromSlice(strings.Split(str, "-"))
+	o.OnParse(err, time.Since(start))
+	return
 }
 
 // FromRaw creates Info instance by raw PAN (xxxxxxxxxxxxxxxx)
 //
-// It checks if len(pan) is 16, and FromSlice is called to create Info instance.
+// It checks if len(pan) is issued by known brands (12 to 19 digits), splits
+// it into groups, and creates Info instance like FromSlice does. Raw PAN is
+// never ambiguous, so WithVariableLength is not needed.
+// ErrUnsupportedLength is returned for other lengths.
 func FromRaw(str string) (ret Info, err error) {
-	if len(str) != 16 {
-		err = ErrRaw
+	return defaultConfig.FromRaw(str)
+}
+
+// FromRaw is same as package-level FromRaw, but uses c
+func (c *Config) FromRaw(str string) (ret Info, err error) {
+	o := c.currentObserver()
+	if o == nil {
+		return c.fromRaw(str)
+	}
+	start := time.Now()
+	ret, err = c.fromRaw(str)
+	o.OnParse(err, time.Since(start))
+	return
+}
+
+func (c *Config) fromRaw(str string) (ret Info, err error) {
+	str, n := c.normalize(str)
+	if layouts(len(str), variableLengths) == nil {
+		err = newErrUnsupportedLength(len(str), variableLengths)
 		return
 	}
 
-	return FromPart(
-		str[:4],
-		str[4:8],
-		str[8:12],
-		str[12:],
-	)
+	ret, err = c.fromGroups(groups(str), variableLengths)
+	return withCorrections(ret, n), err
 }
 
 // FromPart wraps FromSlice, so everything about FromSlice applies to it
 func FromPart(parts ...string) (ret Info, err error) {
-	return FromSlice(parts)
+	return defaultConfig.FromSlice(parts)
+}
+
+// FromPart is same as package-level FromPart, but uses c
+func (c *Config) FromPart(parts ...string) (ret Info, err error) {
+	return c.FromSlice(parts)
 }
 
 // FromMasked creates Info instance with first 6 digits and last 4 digits
@@ -194,9 +352,28 @@ func FromPart(parts ...string) (ret Info, err error) {
 // You can omit any of first6/last4, asterisks are padded to it. But passing more
 // than 6/4 digits is not allowed.
 func FromMasked(first6, last4 string) (ret Info, err error) {
+	return defaultConfig.FromMasked(first6, last4)
+}
+
+// FromMasked is same as package-level FromMasked, but uses c
+func (c *Config) FromMasked(first6, last4 string) (ret Info, err error) {
+	o := c.currentObserver()
+	if o == nil {
+		return c.fromMasked(first6, last4)
+	}
+	start := time.Now()
+	ret, err = c.fromMasked(first6, last4)
+	o.OnParse(err, time.Since(start))
+	return
+}
+
+func (c *Config) fromMasked(first6, last4 string) (ret Info, err error) {
+	first6, n1 := c.normalize(first6)
+	last4, n2 := c.normalize(last4)
 	if len(first6) != 6 || len(last4) != 4 {
 		err = ErrMasked
 		return
 	}
-	return FromPart(first6[:4], first6[4:]+"**", "****", last4)
+	ret, err = c.fromSlice([]string{first6[:4], first6[4:] + "**", "****", last4})
+	return withCorrections(ret, n1+n2), err
 }
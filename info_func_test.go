@@ -5,8 +5,11 @@
 package creditcard
 
 import (
+	"errors"
 	"fmt"
+	"go/build"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 	"testing"
@@ -17,8 +20,22 @@ func TestValidate(t *testing.T) {
 		"0000000000000000": nil,
 		"0000000000000001": ErrValidate,
 		"00000000000000*0": ErrValidateMasked,
-		"0000000000000019": nil,
-		"0000000000000108": nil,
+		"****************": ErrNoDigits,
+		"0000000000000018": nil,
+		"0000000000000109": nil,
+		"0000000000000019": ErrValidate,
+		"0000000000000108": ErrValidate,
+		// scheme test numbers
+		"4111111111111111": nil,
+		"4012888888881881": nil,
+		"5555555555554444": nil,
+		"5105105105105100": nil,
+		"2223000048400011": nil,
+		"3530111333300000": nil,
+		"3566002020360505": nil,
+		"6200000000000005": nil,
+		"4111111111111112": ErrValidate,
+		"5555555555554445": ErrValidate,
 	}
 
 	for pan, expect := range cases {
@@ -37,6 +54,50 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestIsFullyMasked(t *testing.T) {
+	cases := []struct {
+		pan    string
+		expect bool
+	}{
+		{"****************", true},
+		{"411111******1111", false},
+		{"***************1", false},
+		{"4111111111111111", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.pan, func(t *testing.T) {
+			info, err := FromRaw(c.pan)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if actual := info.IsFullyMasked(); actual != c.expect {
+				t.Log("expect:", c.expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+
+			err = info.Validate()
+			if errors.Is(err, ErrValidateMasked) != strings.Contains(c.pan, "*") {
+				t.Fatal("unexpected error:", err)
+			}
+			if (err == ErrNoDigits) != c.expect {
+				t.Log("expect:", c.expect)
+				t.Log("actual:", err)
+				t.Fatal("IsFullyMasked and Validate disagree")
+			}
+		})
+	}
+
+	empty, _ := FromSlice(nil)
+	if !empty.IsFullyMasked() || empty.Validate() != ErrNoDigits {
+		t.Fatal("FromSlice(nil) should be fully masked")
+	}
+	if errors.Is(ErrValidateMasked, ErrNoDigits) {
+		t.Fatal("ErrValidateMasked should not be ErrNoDigits")
+	}
+}
+
 func TestPAN(t *testing.T) {
 	info, err := FromRaw("1234567890123456")
 	if err != nil {
@@ -126,8 +187,33 @@ func TestCardType(t *testing.T) {
 				expect: UnionPay,
 			},
 			{
-				name:   "unionpay81",
-				prefix: "81",
+				name:   "instapayment637",
+				prefix: "637",
+				expect: InstaPaymentCard,
+			},
+			{
+				name:   "instapayment638",
+				prefix: "638",
+				expect: InstaPaymentCard,
+			},
+			{
+				name:   "instapayment639",
+				prefix: "639",
+				expect: InstaPaymentCard,
+			},
+			{
+				name:   "uatp",
+				prefix: "1",
+				expect: UATPCard,
+			},
+			{
+				name:   "troy9792",
+				prefix: "9792",
+				expect: TroyCard,
+			},
+			{
+				name:   "unionpay810",
+				prefix: "810",
 				expect: UnionPay,
 			},
 		}...,
@@ -204,7 +290,7 @@ func testCreation(t *testing.T, f func(creationTestCase) (Info, error), c creati
 	}
 	t.Run(name, func(t *testing.T) {
 		info, err := f(c)
-		if c.err != err {
+		if !errors.Is(err, c.err) {
 			t.Log("expect (err):", c.err)
 			t.Log("actual (err):", err)
 			t.Fatal("unexpected error")
@@ -318,23 +404,28 @@ func TestRawCreation(t *testing.T) {
 			err:   ErrRaw,
 		},
 		{
-			name:  "more1",
-			slice: []string{"12341", "5678", "9012", "3456"},
-			err:   ErrRaw,
+			name:   "more1",
+			slice:  []string{"12341", "5678", "9012", "3456"},
+			expect: "12341567890123456",
 		},
 		{
-			name:  "more2",
-			slice: []string{"1234", "56781", "9012", "3456"},
-			err:   ErrRaw,
+			name:   "more2",
+			slice:  []string{"1234", "56781", "9012", "3456"},
+			expect: "12345678190123456",
 		},
 		{
-			name:  "more3",
-			slice: []string{"1234", "5678", "90121", "3456"},
-			err:   ErrRaw,
+			name:   "more3",
+			slice:  []string{"1234", "5678", "90121", "3456"},
+			expect: "12345678901213456",
 		},
 		{
-			name:  "more4",
-			slice: []string{"1234", "5678", "9012", "34561"},
+			name:   "more4",
+			slice:  []string{"1234", "5678", "9012", "34561"},
+			expect: "12345678901234561",
+		},
+		{
+			name:  "more5",
+			slice: []string{"12341", "56781", "90121", "34561"},
 			err:   ErrRaw,
 		},
 		{
@@ -401,3 +492,380 @@ func TestMaskedCreation(t *testing.T) {
 		}, c, "")
 	}
 }
+
+func TestCardTypeTable(t *testing.T) {
+	// the regexps used before the prefix table, kept as reference
+	ref := []struct {
+		re  *regexp.Regexp
+		typ CardType
+	}{
+		{regexp.MustCompile("^6516"), EloCard},
+		{regexp.MustCompile("^(4026|4508|4844|491[37])"), VisaElectron},
+		{regexp.MustCompile("^3841"), HipercardCard},
+		{regexp.MustCompile("^5019"), DankortCard},
+		{regexp.MustCompile("^9792"), TroyCard},
+		{regexp.MustCompile("^8600"), UzCard},
+		{regexp.MustCompile("^9860"), HumoCard},
+		{regexp.MustCompile("^9704"), NapasCard},
+		{regexp.MustCompile("^31"), ChinaTUnion},
+		{regexp.MustCompile("^1"), UATPCard},
+		{regexp.MustCompile("^63[7-9]"), InstaPaymentCard},
+		{regexp.MustCompile("^4"), VISACard},
+		{regexp.MustCompile("^(5[1-5]|222[1-9]|22[3-9][0-9]|27[01][0-9]|2720)"), MasterCard},
+		{regexp.MustCompile("^3[47]"), AmericanExpress},
+		{regexp.MustCompile("^35(2[89]|[3-8][0-9])"), JCBCard},
+		{regexp.MustCompile("^(508|60(0[0-9]|10|1[2-9]|[2-9][0-9])|652[12]|817[2-9]|81[89][0-9]|82)"), RuPayCard},
+		{regexp.MustCompile("^(62|81)"), UnionPay},
+		{regexp.MustCompile("^(30[0-5]|3095|36|3[89])"), DinersClub},
+		{regexp.MustCompile("^(50|5[6-9]|61|63|64[0-3]|6[6-9])"), MaestroCard},
+		{regexp.MustCompile("^220[0-4]"), MirCard},
+	}
+	expect := func(s string) CardType {
+		for _, r := range ref {
+			if r.re.MatchString(s) {
+				return r.typ
+			}
+		}
+		return UnknownCardType
+	}
+
+	for n := 0; n < 10000; n++ {
+		str := fmt.Sprintf("%04d", n)
+		// mask trailing digits as well, like "35**"
+		for m := 0; m <= 4; m++ {
+			s := str[:4-m] + strings.Repeat("*", m)
+			if actual := defaultConfig.cardType(s); actual != expect(s) {
+				t.Log("expect:", expect(s))
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result of", s)
+			}
+		}
+	}
+}
+
+func TestCardTypeBoundary(t *testing.T) {
+	cases := []struct {
+		pan    string
+		expect CardType
+	}{
+		{"2199000000000007", UnknownCardType},
+		{"2200000000000004", MirCard},
+		{"2204000000000000", MirCard},
+		{"2205000000000007", UnknownCardType},
+		{"2220000000000000", UnknownCardType},
+		{"2221000000000009", MasterCard},
+		{"5070000000000000", MaestroCard},
+		{"5080000000000000", RuPayCard},
+		{"5089990000000000", RuPayCard},
+		{"5090000000000000", EloCard},
+		{"5999000000000000", MaestroCard},
+		{"6000000000000000", RuPayCard},
+		{"6010000000000000", RuPayCard},
+		{"6011000000000000", UnknownCardType}, // Discover
+		{"6012000000000000", RuPayCard},
+		{"6099000000000000", RuPayCard},
+		{"6100000000000000", MaestroCard},
+		{"6520000000000000", UnknownCardType}, // Discover
+		{"6521000000000000", RuPayCard},
+		{"6522000000000000", RuPayCard},
+		{"6523000000000000", UnknownCardType}, // Discover
+		{"8171000000000000", UnionPay},
+		{"8172000000000000", RuPayCard},
+		{"8199000000000000", RuPayCard},
+		{"8200000000000000", RuPayCard},
+		{"8299000000000000", RuPayCard},
+		{"8300000000000000", UnknownCardType},
+		{"6062810000000000", RuPayCard},
+		{"6062820000000000", HipercardCard},
+		{"6062830000000000", RuPayCard},
+		{"3840000000000000", DinersClub},
+		{"3841000000000000", HipercardCard},
+		{"3841990000000000", HipercardCard},
+		{"3842000000000000", DinersClub},
+		{"9791000000000000", UnknownCardType},
+		{"9792000000000000", TroyCard},
+		{"9793000000000000", UnknownCardType},
+		{"6500510000000000", EloCard},
+		{"6500520000000000", TroyCard},
+		{"6501590000000000", TroyCard},
+		{"6501600000000000", UnknownCardType}, // Discover
+		{"5060980000000000", MaestroCard},
+		{"5060990000000000", VerveCard},
+		{"5061980000000000", VerveCard},
+		{"5061990000000000", MaestroCard},
+		{"5078640000000000", MaestroCard},
+		{"5078650000000000", VerveCard},
+		{"5079640000000000", VerveCard},
+		{"5079650000000000", MaestroCard},
+		{"6500010000000000", UnknownCardType}, // Discover
+		{"6500020000000000", VerveCard},
+		{"6500270000000000", VerveCard},
+		{"6500280000000000", UnknownCardType}, // Discover
+		{"6500310000000000", EloCard},
+		{"6369990000000000", MaestroCard},
+		{"6370000000000000", InstaPaymentCard},
+		{"6399990000000000", InstaPaymentCard},
+		{"6400000000000000", MaestroCard},
+		{"6007210000000000", RuPayCard},
+		{"6007220000000000", Forbrugsforeningen},
+		{"6007220123456789", Forbrugsforeningen},
+		{"6007230000000000", RuPayCard},
+		{"8599000000000000", UnknownCardType},
+		{"8600000000000000", UzCard},
+		{"8601000000000000", UnknownCardType},
+		{"9859000000000000", UnknownCardType},
+		{"9860000000000000", HumoCard},
+		{"9861000000000000", UnknownCardType},
+		{"3099000000000000", UnknownCardType},
+		{"3100000000000000", ChinaTUnion},
+		{"3199000000000000", ChinaTUnion},
+		{"3200000000000000", UnknownCardType},
+		{"3400000000000000", AmericanExpress},
+		{"3528000000000000", JCBCard},
+		{"3700000000000000", AmericanExpress},
+		{"9703990000000000", UnknownCardType},
+		{"9704000000000000", NapasCard},
+		{"9705000000000000", UnknownCardType},
+	}
+	for _, c := range cases {
+		info, err := FromRaw(c.pan)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if actual := info.CardType(); actual != c.expect {
+			t.Log("expect:", c.expect)
+			t.Log("actual:", actual)
+			t.Fatal("unexpected card type of", c.pan)
+		}
+		masked, err := FromMasked(c.pan[:6], c.pan[len(c.pan)-4:])
+		if err != nil || masked.CardType() != c.expect {
+			t.Fatal("unexpected card type of masked", c.pan, masked.CardType(), err)
+		}
+	}
+}
+
+func TestCardTypePrefix(t *testing.T) {
+	cases := []struct {
+		pan    string
+		expect CardType
+	}{
+		{"", UnknownCardType},
+		{"*", UnknownCardType},
+		{"4", VISACard},
+		{"4*", VISACard},
+		{"*4", UnknownCardType},
+		{"40117", VISACard},
+		{"401178", EloCard},
+		{"40117*8", VISACard},
+		{"4011780", EloCard},
+		{"401178**********", EloCard},
+		{"4175", VISACard},
+		{"417500", VisaElectron},
+		{"417501", VISACard},
+		{"417500******0001", VisaElectron},
+		{"3", UnknownCardType},
+		{"34", AmericanExpress},
+		{"35", UnknownCardType},
+		{"3528", JCBCard},
+		{"352*", UnknownCardType},
+		{"6", UnknownCardType},
+		{"60", UnknownCardType},
+		{"600", UnknownCardType},
+		{"6007", RuPayCard},
+		{"600722", Forbrugsforeningen},
+		{"6007229", Forbrugsforeningen},
+		{"60072290", Forbrugsforeningen},
+		// digits after first 8 are ignored
+		{"401178901234567890", EloCard},
+		{"5555555555554444", MasterCard},
+		{"6200000000000005", UnionPay},
+	}
+	for _, c := range cases {
+		if actual := builtinCardType(c.pan); actual != c.expect {
+			t.Log("expect:", c.expect)
+			t.Log("actual:", actual)
+			t.Fatal("unexpected card type of", c.pan)
+		}
+	}
+
+	// FromMasked knows first 6 digits
+	masked := []struct {
+		first6 string
+		expect CardType
+	}{
+		{"401178", EloCard},
+		{"417500", VisaElectron},
+		{"606282", HipercardCard},
+		{"506099", VerveCard},
+		{"650052", TroyCard},
+		{"600722", Forbrugsforeningen},
+		{"411111", VISACard},
+		{"378282", AmericanExpress},
+	}
+	for _, c := range masked {
+		info, err := FromMasked(c.first6, "0000")
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if actual := info.CardType(); actual != c.expect {
+			t.Log("expect:", c.expect)
+			t.Log("actual:", actual)
+			t.Fatal("unexpected card type of", c.first6)
+		}
+	}
+}
+
+func TestElo(t *testing.T) {
+	bins := []string{
+		"401178", "401179", "431274", "438935", "451416", "457393", "457631",
+		"457632", "504175", "506699", "506778", "509000", "509999", "627780",
+		"636297", "636368", "650031", "650051", "650405", "650439", "650485",
+		"650538", "650541", "650598", "650700", "650718", "650720", "650727",
+		"650901", "650978", "651600", "651699", "655000", "655058",
+	}
+	for _, bin := range bins {
+		info, err := FromMasked(bin, "0000")
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if actual := info.CardType(); actual != EloCard {
+			t.Log("expect:", EloCard)
+			t.Log("actual:", actual)
+			t.Fatal("unexpected card type of", bin)
+		}
+	}
+
+	// neighbors
+	for bin, expect := range map[string]CardType{
+		"401177": VISACard,
+		"401180": VISACard,
+		"411111": VISACard,
+		"457630": VISACard,
+		"506698": MaestroCard,
+		"506779": MaestroCard,
+		"508999": RuPayCard,
+		"627779": UnionPay,
+		"636369": MaestroCard,
+		"650030": UnknownCardType,
+		"655059": UnknownCardType,
+	} {
+		info, err := FromMasked(bin, "0000")
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if actual := info.CardType(); actual != expect {
+			t.Log("expect:", expect)
+			t.Log("actual:", actual)
+			t.Fatal("unexpected card type of", bin)
+		}
+	}
+}
+
+func TestNoRegexp(t *testing.T) {
+	// core package is compiled to wasm with tinygo, where regexp is costly
+	ctx := build.Default
+	ctx.GOOS, ctx.GOARCH = "js", "wasm"
+	seen := map[string]bool{}
+	var walk func(path, srcDir string)
+	walk = func(path, srcDir string) {
+		pkg, err := ctx.Import(path, srcDir, 0)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		for _, imp := range pkg.Imports {
+			if imp == "regexp" {
+				t.Fatal(path, "imports regexp")
+			}
+			if imp == "C" || imp == "unsafe" || seen[imp] {
+				continue
+			}
+			seen[imp] = true
+			walk(imp, pkg.Dir)
+		}
+	}
+	walk(".", ".")
+}
+
+func TestAllCardTypes(t *testing.T) {
+	expect := []CardType{VISACard, MasterCard, JCBCard, AmericanExpress, UnionPay, DinersClub, MaestroCard, MirCard, RuPayCard, EloCard, HipercardCard, DankortCard, TroyCard, VerveCard, UATPCard, InstaPaymentCard, VisaElectron, CarteBancaire, Forbrugsforeningen, UzCard, HumoCard, NapasCard, ChinaTUnion}
+	actual := AllCardTypes()
+	if !reflect.DeepEqual(expect, actual) {
+		t.Log("expect:", expect)
+		t.Log("actual:", actual)
+		t.Fatal("unexpected result")
+	}
+
+	for _, typ := range actual {
+		if !typ.Known() || !typ.IsKnown() {
+			t.Fatal("unexpected unknown type:", typ)
+		}
+		b := brands[typ]
+		if b.name == "" || b.audit == "" || b.ui.LogoSlug == "" {
+			t.Fatal("missing name of", typ)
+		}
+		if b.audit != strings.ToUpper(b.audit) || b.audit == "UNKNOWN" {
+			t.Fatal("unexpected audit name of", typ, b.audit)
+		}
+		if len(b.ranges) == 0 && typ != CarteBancaire {
+			t.Fatal("missing prefix ranges of", typ)
+		}
+		if len(b.lengths) == 0 || len(b.gaps) == 0 {
+			t.Fatal("missing brand lengths of", typ)
+		}
+		for _, l := range b.lengths {
+			if layouts(l, variableLengths) == nil {
+				t.Fatal("missing layout of", typ, l)
+			}
+		}
+	}
+
+	for _, typ := range []CardType{UnknownCardType, beginKnownCardType, endKnownCardType, 100} {
+		if typ.Known() || typ.IsKnown() {
+			t.Fatal("unexpected known type:", typ)
+		}
+	}
+
+	// modifying returned slice does not affect later calls
+	actual[0] = UnknownCardType
+	if actual = AllCardTypes(); actual[0] != VISACard {
+		t.Fatal("unexpected result after modification:", actual)
+	}
+}
+
+func TestNoPadding(t *testing.T) {
+	strict := New(WithNoPadding())
+	full := []string{"4111", "1111", "1100", "0066"}
+	for idx := range full {
+		for _, short := range []string{"", "41", "***"} {
+			arr := append([]string(nil), full...)
+			arr[idx] = short
+			_, err := strict.FromSlice(arr)
+			if expect := (ErrSectionValue{Index: idx, Width: 4}); err != expect {
+				t.Log("expect:", expect)
+				t.Log("actual:", err)
+				t.Fatalf("unexpected error of %q", arr)
+			}
+			if !errors.Is(err, ErrSection) {
+				t.Fatal("unexpected error:", err)
+			}
+
+			arr = append([]string(nil), full...)
+			arr[idx] = short
+			if _, err = FromSlice(arr); err != nil {
+				t.Fatalf("unexpected error of %q in lenient mode: %v", arr, err)
+			}
+		}
+	}
+
+	if _, err := strict.FromDashed("4111-1111-1100"); err != (ErrSectionValue{Index: 3, Width: 4}) {
+		t.Fatal("unexpected error:", err)
+	}
+	for _, pan := range []string{"4111-1111-1100-0066", "4111-11**-****-0066", "4111111111000066"} {
+		if _, err := strict.Parse(pan); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+	if info, err := strict.FromMasked("411111", "0066"); err != nil || info.RawPAN() != "411111******0066" {
+		t.Fatal("unexpected result:", info, err)
+	}
+}
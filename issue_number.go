@@ -0,0 +1,28 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+// ErrIssueNumber is returned by WithIssueNumber if the number is out of range
+const ErrIssueNumber ErrArgument = "issue number must be 0-99"
+
+func (i *info) WithIssueNumber(n int) (ret Info, err error) {
+	if i.IsZero() {
+		return nil, ErrNoCard
+	}
+	if n < 0 || n > 99 {
+		return nil, ErrIssueNumber
+	}
+
+	x := *i
+	x.issue, x.hasIssue = n, true
+	return &x, nil
+}
+
+func (i *info) IssueNumber() (ret int, ok bool) {
+	if i.IsZero() {
+		return
+	}
+	return i.issue, i.hasIssue
+}
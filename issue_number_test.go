@@ -0,0 +1,47 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import "testing"
+
+func TestIssueNumber(t *testing.T) {
+	info, _ := FromRaw("4111111111111111")
+	if _, ok := info.IssueNumber(); ok {
+		t.Fatal("issue number should not be attached")
+	}
+
+	for _, n := range []int{0, 1, 99} {
+		x, err := info.WithIssueNumber(n)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if actual, ok := x.IssueNumber(); !ok || actual != n {
+			t.Log("expect:", n)
+			t.Log("actual:", actual, ok)
+			t.Fatal("unexpected result")
+		}
+		if _, ok := x.Sequence(); ok {
+			t.Fatal("issue number is attached as sequence")
+		}
+		if x.RawPAN() != info.RawPAN() || x.CardType() != info.CardType() {
+			t.Fatal("pan is changed")
+		}
+	}
+	if _, ok := info.IssueNumber(); ok {
+		t.Fatal("original info is modified")
+	}
+
+	for _, n := range []int{-1, 100} {
+		if _, err := info.WithIssueNumber(n); err != ErrIssueNumber {
+			t.Log("expect:", ErrIssueNumber)
+			t.Log("actual:", err)
+			t.Fatal("unexpected error")
+		}
+	}
+
+	if _, err := NoCard.WithIssueNumber(1); err != ErrNoCard {
+		t.Fatal("unexpected error:", err)
+	}
+}
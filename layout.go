@@ -0,0 +1,115 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import "strings"
+
+// digit groups of supported PAN lengths, used by PAN and Masked
+//
+// FromRaw accepts all of them, FromSlice accepts only 14, 15 and 16 digits
+// by default, see defaultLengths. The first layout of a length is used by
+// FromRaw, others are accepted by FromSlice and kept for output.
+var panLayouts = map[int][][]int{
+	12: {{4, 4, 4}},
+	13: {{4, 4, 5}},
+	14: {{4, 6, 4}}, // Diners Club
+	15: {{4, 6, 5}}, // AMEX
+	16: {{4, 4, 4, 4}},
+	17: {{4, 4, 4, 5}},
+	18: {{4, 4, 4, 6}},
+	19: {{4, 4, 4, 4, 3}, {6, 13}}, // 6-13 is used by UnionPay
+}
+
+// lengths accepted by FromSlice unless WithVariableLength is used
+//
+// Layouts of other lengths collide with 16-digit PANs padded by FromSlice,
+// like "4111-1111-1111" which is padded to 16 digits by default.
+var defaultLengths = []int{14, 15, 16}
+
+// lengths accepted by FromRaw, and by FromSlice if WithVariableLength is
+// used, which are lengths issued by known brands
+var variableLengths = issuedLengths()
+
+// groups splits s by the first layout of its length
+func groups(s string) (ret []string) {
+	return split(s, panLayouts[len(s)][0])
+}
+
+// split splits s by layout
+func split(s string, layout []int) (ret []string) {
+	ret = make([]string, 0, len(layout))
+	pos := 0
+	for _, w := range layout {
+		ret = append(ret, s[pos:pos+w])
+		pos += w
+	}
+	return
+}
+
+// isPANChars reports if v is composed by digits or asterisks
+func isPANChars(v string) (ret bool) {
+	for idx := 0; idx < len(v); idx++ {
+		if c := v[idx]; c != '*' && !isDigit(c) {
+			return
+		}
+	}
+	return true
+}
+
+// lengths returns lengths of grouped PAN accepted by c in ascending order
+func (c *Config) lengths() (ret []int) {
+	if c.variableLength {
+		return variableLengths
+	}
+	return defaultLengths
+}
+
+// layouts returns digit groups of l-digit PANs, nil if l is not in lengths
+func layouts(l int, lengths []int) (ret [][]int) {
+	for _, x := range lengths {
+		if x == l {
+			return panLayouts[l]
+		}
+	}
+	return
+}
+
+// joinLayout joins arr if it is exactly a layout of lengths, returning the
+// index of the layout in panLayouts
+func joinLayout(arr []string, lengths []int) (ret string, idx int, ok bool) {
+	total := 0
+	for _, v := range arr {
+		total += len(v)
+	}
+	for idx, layout := range layouts(total, lengths) {
+		if isLayout(arr, layout) {
+			return strings.Join(arr, ""), idx, true
+		}
+	}
+	return
+}
+
+// isLayout reports if arr is exactly grouped by layout
+func isLayout(arr []string, layout []int) (ret bool) {
+	if len(layout) != len(arr) {
+		return
+	}
+	for idx, w := range layout {
+		if len(arr[idx]) != w || !isPANChars(arr[idx]) {
+			return
+		}
+	}
+	return true
+}
+
+// groups splits s, which has same length as i.pan, by layout of i
+func (i *info) groups(s string) (ret []string) {
+	return split(s, panLayouts[len(s)][i.layout])
+}
+
+// format groups s, which has same length as i.pan, by the separator
+func (i *info) format(s string) (ret string) {
+	return strings.Join(i.groups(s), i.config().Separator())
+}
@@ -0,0 +1,489 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// accepts PANs of 12 to 19 digits
+var variableConfig = New(WithVariableLength())
+
+func TestAMEX(t *testing.T) {
+	for _, pan := range []string{"378282246310005", "371449635398431", "378734493671000"} {
+		t.Run(pan, func(t *testing.T) {
+			dashed := pan[:4] + "-" + pan[4:10] + "-" + pan[10:]
+			for _, f := range []func() (Info, error){
+				func() (Info, error) { return FromRaw(pan) },
+				func() (Info, error) { return FromDashed(dashed) },
+				func() (Info, error) { return FromSlice([]string{pan[:4], pan[4:10], pan[10:]}) },
+				func() (Info, error) { return Parse(pan) },
+				func() (Info, error) { return ParseTrusted(pan), nil },
+			} {
+				info, err := f()
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+				if info.CardType() != AmericanExpress {
+					t.Fatal("unexpected card type:", info.CardType())
+				}
+				if err = info.Validate(); err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+				if info.RawPAN() != pan || info.PAN() != dashed {
+					t.Fatal("unexpected pan:", info.RawPAN(), info.PAN())
+				}
+				if info.First6() != pan[:6] || info.Last4() != pan[11:] || info.Checksum() != pan[14:] {
+					t.Fatal("unexpected digits:", info.First6(), info.Last4(), info.Checksum())
+				}
+			}
+		})
+	}
+}
+
+func TestAMEXMasked(t *testing.T) {
+	info, err := FromRaw("378282246310005")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	cases := []struct {
+		name, expect, actual string
+	}{
+		{"RawMasked", "378282*****0005", info.RawMasked()},
+		{"Masked", "3782-82****-*0005", info.Masked()},
+		{"FullFirst6", "3782-82****-*****", info.FullFirst6()},
+		{"FullLast4", "****-******-*0005", info.FullLast4()},
+	}
+	for _, c := range cases {
+		if c.actual != c.expect {
+			t.Log("expect:", c.expect)
+			t.Log("actual:", c.actual)
+			t.Fatal("unexpected result of", c.name)
+		}
+	}
+
+	masked, err := Parse(info.Masked())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if masked.CardType() != AmericanExpress || masked.Validate() != ErrValidateMasked {
+		t.Fatal("unexpected result:", masked.CardType(), masked.Validate())
+	}
+
+	x, err := info.WithSection(2, "10006")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if x.RawPAN() != "378282246310006" || !errors.Is(x.Validate(), ErrValidate) {
+		t.Fatal("unexpected result:", x.RawPAN(), x.Validate())
+	}
+	if _, err = info.WithSection(3, "0000"); err != (ErrSectionValue{Index: 3}) {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestDinersClub(t *testing.T) {
+	cases := []struct {
+		pan, dashed, masked string
+	}{
+		{"30569309025904", "3056-930902-5904", "3056-93****-5904"},
+		{"38520000023237", "3852-000002-3237", "3852-00****-3237"},
+	}
+	for _, c := range cases {
+		info, err := FromRaw(c.pan)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if info.CardType() != DinersClub {
+			t.Fatal("unexpected card type of", c.pan, info.CardType())
+		}
+		if err = info.Validate(); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if info.PAN() != c.dashed || info.Masked() != c.masked || info.RawMasked() != c.pan[:6]+"****"+c.pan[10:] {
+			t.Fatal("unexpected result:", info.PAN(), info.Masked(), info.RawMasked())
+		}
+
+		dashed, err := Parse(c.dashed)
+		if err != nil || dashed.RawPAN() != c.pan {
+			t.Fatal("unexpected result:", dashed, err)
+		}
+
+		bad, _ := info.WithSection(2, "0000")
+		if !errors.Is(bad.Validate(), ErrValidate) {
+			t.Fatal("unexpected error:", bad.Validate())
+		}
+	}
+}
+
+func TestMaestro(t *testing.T) {
+	cases := []struct {
+		pan, dashed, masked string
+	}{
+		{"501800000009", "5018-0000-0009", "5018-00**-0009"},
+		{"6759649826430", "6759-6498-26430", "6759-64**-*6430"},
+		{"67596498264388", "6759-649826-4388", "6759-64****-4388"},
+		{"675964982643844", "6759-649826-43844", "6759-64****-*3844"},
+		{"6759649826438453", "6759-6498-2643-8453", "6759-64**-****-8453"},
+		{"67596498264384533", "6759-6498-2643-84533", "6759-64**-****-*4533"},
+		{"679999010000000003", "6799-9901-0000-000003", "6799-99**-****-**0003"},
+		{"6799990100000000019", "6799-9901-0000-0000-019", "6799-99**-****-***0-019"},
+	}
+	for _, c := range cases {
+		info, err := variableConfig.FromRaw(c.pan)
+		if err != nil {
+			t.Fatal("unexpected error of", c.pan, err)
+		}
+		if info.CardType() != MaestroCard {
+			t.Fatal("unexpected card type of", c.pan, info.CardType())
+		}
+		if err = info.Validate(); err != nil {
+			t.Fatal("unexpected error of", c.pan, err)
+		}
+		if info.First6() != c.pan[:6] || info.Last4() != c.pan[len(c.pan)-4:] {
+			t.Fatal("unexpected digits:", info.First6(), info.Last4())
+		}
+		expect := c.pan[:6] + strings.Repeat("*", len(c.pan)-10) + c.pan[len(c.pan)-4:]
+		if actual := info.RawMasked(); actual != expect {
+			t.Log("expect:", expect)
+			t.Log("actual:", actual)
+			t.Fatal("unexpected masked pan")
+		}
+		if info.PAN() != c.dashed || info.Masked() != c.masked {
+			t.Fatal("unexpected result:", info.PAN(), info.Masked())
+		}
+
+		for _, s := range []string{c.dashed, c.masked} {
+			x, err := variableConfig.Parse(s)
+			if err != nil {
+				t.Fatal("unexpected error of", s, err)
+			}
+			if x.PAN() != s || x.CardType() != MaestroCard {
+				t.Fatal("unexpected result of", s, x.PAN(), x.CardType())
+			}
+		}
+	}
+
+	// prefixes of other brands take precedence
+	for pan, expect := range map[string]CardType{
+		"5105105105105100": MasterCard,
+		"6200000000000005": UnionPay,
+		"6011000990139424": UnknownCardType, // Discover
+		"6500000000000002": UnknownCardType, // Discover
+		"6304000000000000": MaestroCard,
+	} {
+		info, err := variableConfig.FromRaw(pan)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if actual := info.CardType(); actual != expect {
+			t.Log("expect:", expect)
+			t.Log("actual:", actual)
+			t.Fatal("unexpected card type of", pan)
+		}
+	}
+}
+
+func TestVerve(t *testing.T) {
+	cases := []struct {
+		pan, masked string
+	}{
+		{"5060991234567895", "5060-99**-****-7895"},
+		{"6500020000000000", "6500-02**-****-0000"},
+		{"5060991234567890129", "5060-99**-****-***0-129"},
+		{"5078650000000000129", "5078-65**-****-***0-129"},
+	}
+	for _, c := range cases {
+		info, err := FromRaw(c.pan)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if info.CardType() != VerveCard {
+			t.Fatal("unexpected card type of", c.pan, info.CardType())
+		}
+		if err = info.Validate(); err != nil {
+			t.Fatal("unexpected error of", c.pan, err)
+		}
+		if err = BrandLength.Validate(info); err != nil {
+			t.Fatal("unexpected error of", c.pan, err)
+		}
+		if info.Masked() != c.masked || info.RawMasked() != c.pan[:6]+strings.Repeat("*", len(c.pan)-10)+c.pan[len(c.pan)-4:] {
+			t.Fatal("unexpected result:", info.Masked(), info.RawMasked())
+		}
+	}
+}
+
+func TestNapas(t *testing.T) {
+	cases := []struct {
+		pan, masked string
+	}{
+		{"9704120000000006", "9704-12**-****-0006"},
+		{"97041200000000003", "9704-12**-****-*0003"},
+		{"970412000000000006", "9704-12**-****-**0006"},
+		{"9704120000000000003", "9704-12**-****-***0-003"},
+	}
+	for _, c := range cases {
+		info, err := FromRaw(c.pan)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if info.CardType() != NapasCard {
+			t.Fatal("unexpected card type of", c.pan, info.CardType())
+		}
+		if err = info.Validate(); err != nil {
+			t.Fatal("unexpected error of", c.pan, err)
+		}
+		if err = BrandLength.Validate(info); err != nil {
+			t.Fatal("unexpected error of", c.pan, err)
+		}
+		if info.Masked() != c.masked {
+			t.Fatal("unexpected result:", info.Masked())
+		}
+	}
+
+	info, err := FromMasked("970412", "3456")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if info.CardType() != NapasCard || info.Masked() != "9704-12**-****-3456" {
+		t.Fatal("unexpected result:", info.CardType(), info.Masked())
+	}
+}
+
+func TestChinaTUnion(t *testing.T) {
+	for _, pan := range []string{"3100000000000000005", "3198765432101234564"} {
+		info, err := FromRaw(pan)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if x, err := Parse(pan); err != nil || !reflect.DeepEqual(x, info) {
+			t.Fatal("unexpected result of Parse:", x, err)
+		}
+		if info.CardType() != ChinaTUnion {
+			t.Fatal("unexpected card type of", pan, info.CardType())
+		}
+		if err = info.Validate(); err != nil {
+			t.Fatal("unexpected error of", pan, err)
+		}
+		if err = BrandLength.Validate(info); err != nil {
+			t.Fatal("unexpected error of", pan, err)
+		}
+	}
+
+	// 16-digit T-Union pan is not issued
+	info, _ := FromRaw("3100000000000009")
+	if err := BrandLength.Validate(info); err == nil {
+		t.Fatal("expected error of 16-digit pan")
+	}
+
+	// neighbours of 31 are not affected
+	cases := []struct {
+		pan    string
+		expect CardType
+	}{
+		{"378282246310005", AmericanExpress},
+		{"341111111111111", AmericanExpress},
+		{"3530111333300000", JCBCard},
+		{"3566002020360505", JCBCard},
+	}
+	for _, c := range cases {
+		info, err := FromRaw(c.pan)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if info.CardType() != c.expect {
+			t.Log("expect:", c.expect)
+			t.Log("actual:", info.CardType())
+			t.Fatal("unexpected card type of", c.pan)
+		}
+	}
+}
+
+func TestUATP(t *testing.T) {
+	info, err := FromRaw("135410014004955")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if info.CardType() != UATPCard {
+		t.Fatal("unexpected card type:", info.CardType())
+	}
+	if err = Chain(Luhn, BrandLength).Validate(info); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if actual := info.RawMasked(); actual != "135410*****4955" {
+		t.Fatal("unexpected result:", actual)
+	}
+
+	masked, err := Parse(info.Masked())
+	if err != nil || masked.RawMasked() != info.RawMasked() || masked.CardType() != UATPCard {
+		t.Fatal("unexpected result:", masked, err)
+	}
+	if _, err = FromRaw("0354100140049557"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestLongPAN(t *testing.T) {
+	cases := []struct {
+		pan, dashed string
+		typ         CardType
+	}{
+		{"62123456789012347", "6212-3456-7890-12347", UnionPay},
+		{"621234567890123457", "6212-3456-7890-123457", UnionPay},
+		{"6212345678901234569", "6212-3456-7890-1234-569", UnionPay},
+		{"4111111111111111110", "4111-1111-1111-1111-110", VISACard},
+	}
+	for _, c := range cases {
+		info, err := variableConfig.FromRaw(c.pan)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if info.CardType() != c.typ || info.PAN() != c.dashed {
+			t.Fatal("unexpected result:", info.CardType(), info.PAN())
+		}
+		if err = Chain(Luhn, BrandLength).Validate(info); err != nil {
+			t.Fatal("unexpected error of", c.pan, err)
+		}
+		if info.First6() != c.pan[:6] || info.Last4() != c.pan[len(c.pan)-4:] {
+			t.Fatal("unexpected digits:", info.First6(), info.Last4())
+		}
+
+		for _, s := range []string{info.PAN(), info.Canonical()} {
+			x, err := variableConfig.Parse(s)
+			if err != nil || !reflect.DeepEqual(x, info) {
+				t.Fatal("round trip failed for", s, err)
+			}
+		}
+		x, err := variableConfig.FromSlice(strings.Split(c.dashed, "-"))
+		if err != nil || !reflect.DeepEqual(x, info) {
+			t.Fatal("unexpected result:", x, err)
+		}
+		if x, err = variableConfig.Parse(info.Masked()); err != nil || x.Masked() != info.Masked() {
+			t.Fatal("unexpected result:", x, err)
+		}
+	}
+}
+
+func TestLongPANDefault(t *testing.T) {
+	for _, pan := range []string{
+		"62123456789012347",
+		"621234567890123457",
+		"6212345678901234569",
+		"4111111111111111110",
+		"3100000000000000009",
+	} {
+		info, err := FromRaw(pan)
+		if err != nil {
+			t.Fatal("unexpected error of", pan, err)
+		}
+		if info.RawPAN() != pan {
+			t.Fatal("unexpected result:", info.RawPAN())
+		}
+		x, err := Parse(info.Canonical())
+		if err != nil || !reflect.DeepEqual(x, info) {
+			t.Fatal("round trip failed for", pan, err)
+		}
+	}
+}
+
+func TestLayoutMismatch(t *testing.T) {
+	// groups must match the layout exactly
+	for _, arr := range [][]string{
+		{"3782", "82246", "310005"},
+		{"3782", "822463", "100"},
+		{"3782", "822463", "1000a"},
+	} {
+		if _, err := FromSlice(arr); err != ErrSection {
+			t.Fatal("unexpected error of", arr, err)
+		}
+	}
+}
+
+func TestVariableLength(t *testing.T) {
+	// 4-digit groups keep 16-digit semantics by default
+	info, err := FromDashed("4111-1111-1111")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if actual := info.RawPAN(); actual != "411111111111****" {
+		t.Fatal("unexpected result:", actual)
+	}
+	if _, err = FromDashed("4111-1111-1111-11111"); err != ErrSection {
+		t.Fatal("unexpected error:", err)
+	}
+	// raw PANs are not ambiguous
+	for _, pan := range []string{"411111111111", "41111111111111111", "4111111111111111110"} {
+		info, err := FromRaw(pan)
+		if err != nil {
+			t.Fatal("unexpected error of", pan, err)
+		}
+		if actual := info.RawPAN(); actual != pan {
+			t.Fatal("unexpected result of", pan, actual)
+		}
+	}
+
+	cases := map[string]string{
+		"4111-1111-1111":          "411111111111",
+		"4111-1111-11111":         "4111111111111",
+		"4111-1111-1111-11111":    "41111111111111111",
+		"4111-1111-1111-1111-111": "4111111111111111111",
+		"4111-1111-11":            "4111111111******",
+	}
+	for dashed, expect := range cases {
+		info, err := variableConfig.FromDashed(dashed)
+		if err != nil {
+			t.Fatal("unexpected error of", dashed, err)
+		}
+		if actual := info.RawPAN(); actual != expect {
+			t.Log("expect:", expect)
+			t.Log("actual:", actual)
+			t.Fatal("unexpected result of", dashed)
+		}
+	}
+}
+
+func TestDashedLongPAN(t *testing.T) {
+	const pan = "6212345678901234569"
+	cases := []struct {
+		dashed, masked string
+	}{
+		{"6212-3456-7890-1234-569", "6212-34**-****-***4-569"},
+		{"621234-5678901234569", "621234-*********4569"},
+	}
+	for _, c := range cases {
+		t.Run(c.dashed, func(t *testing.T) {
+			info, err := variableConfig.FromDashed(c.dashed)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if info.CardType() != UnionPay || info.Validate() != nil {
+				t.Fatal("unexpected result:", info.CardType(), info.Validate())
+			}
+			if info.RawPAN() != pan || info.PAN() != c.dashed {
+				t.Log("expect:", pan, c.dashed)
+				t.Log("actual:", info.RawPAN(), info.PAN())
+				t.Fatal("unexpected pan")
+			}
+			if info.Masked() != c.masked {
+				t.Log("expect:", c.masked)
+				t.Log("actual:", info.Masked())
+				t.Fatal("unexpected masked pan")
+			}
+
+			x, err := variableConfig.FromDashed(info.PAN())
+			if err != nil || !reflect.DeepEqual(x, info) {
+				t.Fatal("round trip failed:", x, err)
+			}
+		})
+	}
+
+	_, err := variableConfig.FromDashed("6212-3456-7890-1234-56901")
+	if !errors.Is(err, ErrSection) {
+		t.Fatal("expect ErrSection for 21 digits, got", err)
+	}
+}
@@ -0,0 +1,48 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import "strconv"
+
+// ErrUnsupportedLength is returned by FromRaw if length of the PAN is not
+// supported
+//
+// errors.Is(err, ErrRaw) is true for it. It is returned as pointer since it
+// contains a slice, comparing it with == does not panic.
+type ErrUnsupportedLength struct {
+	Got       int    // length of the input in bytes
+	Supported []int  // lengths accepted by FromRaw
+	Hint      string // human readable suggestion to get the input accepted
+}
+
+func newErrUnsupportedLength(got int, supported []int) (ret *ErrUnsupportedLength) {
+	return &ErrUnsupportedLength{
+		Got:       got,
+		Supported: append([]int(nil), supported...),
+		Hint:      lengthHint(got),
+	}
+}
+
+// lengthHint explains why PAN of length l is rejected by FromRaw
+func lengthHint(l int) (ret string) {
+	n := strconv.Itoa(l)
+	switch {
+	case l == 0:
+		return "input is empty"
+	case l > 19:
+		return "pan has at most 19 digits, check for extra characters like spaces"
+	}
+
+	return n + "-digit pans are not issued by supported brands, check for missing or extra digits"
+}
+
+func (e *ErrUnsupportedLength) Error() (ret string) {
+	return ErrRaw.Error() + ": got " + strconv.Itoa(e.Got) + ", " + e.Hint
+}
+
+// Is reports if target is ErrRaw
+func (e *ErrUnsupportedLength) Is(target error) (ret bool) {
+	return target == ErrRaw
+}
@@ -0,0 +1,66 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestErrUnsupportedLength(t *testing.T) {
+	cases := []struct {
+		pan  string
+		hint string
+	}{
+		{"4111", "4-digit pans are not issued by supported brands, check for missing or extra digits"},
+		{"41111111111", "11-digit pans are not issued by supported brands, check for missing or extra digits"},
+		{"41111111111111110000", "pan has at most 19 digits, check for extra characters like spaces"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.pan, func(t *testing.T) {
+			_, err := FromRaw(c.pan)
+			if !errors.Is(err, ErrRaw) {
+				t.Fatal("unexpected error:", err)
+			}
+
+			var actual *ErrUnsupportedLength
+			if !errors.As(err, &actual) {
+				t.Fatal("unexpected error type:", err)
+			}
+			expect := &ErrUnsupportedLength{
+				Got:       len(c.pan),
+				Supported: []int{12, 13, 14, 15, 16, 17, 18, 19},
+				Hint:      c.hint,
+			}
+			if !reflect.DeepEqual(actual, expect) {
+				t.Log("expect:", expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+			if !strings.HasPrefix(err.Error(), ErrRaw.Error()) {
+				t.Fatal("unexpected message:", err)
+			}
+		})
+	}
+
+	// Supported must not be shared
+	_, err := FromRaw("4111")
+	err.(*ErrUnsupportedLength).Supported[0] = 0
+	if variableLengths[0] != 12 {
+		t.Fatal("supported lengths are modified")
+	}
+
+	// WithVariableLength does not change lengths of raw PAN
+	_, err = New(WithVariableLength()).FromRaw("41111111111")
+	_, expect := FromRaw("41111111111")
+	if !reflect.DeepEqual(err, expect) {
+		t.Log("expect:", expect)
+		t.Log("actual:", err)
+		t.Fatal("unexpected result of variable length")
+	}
+}
@@ -0,0 +1,18 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+module github.com/raohwork/creditcard/logruscard
+
+go 1.20
+
+require (
+	github.com/raohwork/creditcard v1.0.0
+	github.com/sirupsen/logrus v1.9.3
+)
+
+require golang.org/x/sys v0.0.0-20220715151400-c0bba94af5f8 // indirect
+
+// for developing in this repository only, replace directives are ignored
+// when this module is used as a dependency
+replace github.com/raohwork/creditcard => ../
@@ -0,0 +1,41 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+// Package logruscard provides a logrus hook which scrubs PAN-like values
+//
+// It lives in its own module so the main package stays dependency-free.
+package logruscard
+
+import (
+	"github.com/raohwork/creditcard"
+	"github.com/sirupsen/logrus"
+)
+
+type hook struct {
+	s *creditcard.Scrubber
+}
+
+// NewLogrusHook creates a logrus.Hook which masks PAN-like sequences in
+// message and string fields of every entry, see creditcard.Redact
+//
+// It fires on all levels. Fields of other types are left alone.
+func NewLogrusHook(opts ...creditcard.ScrubOption) (ret logrus.Hook) {
+	return &hook{s: creditcard.NewScrubber(opts...)}
+}
+
+func (h *hook) Levels() (ret []logrus.Level) {
+	return logrus.AllLevels
+}
+
+func (h *hook) Fire(e *logrus.Entry) (err error) {
+	e.Message = h.s.Scrub(e.Message)
+	for k, v := range e.Data {
+		str, ok := v.(string)
+		if !ok || h.s.SkipKey(k) {
+			continue
+		}
+		e.Data[k] = h.s.Scrub(str)
+	}
+	return
+}
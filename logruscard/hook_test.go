@@ -0,0 +1,69 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package logruscard
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/raohwork/creditcard"
+	"github.com/sirupsen/logrus"
+)
+
+// memFormatter records entries instead of rendering them
+type memFormatter struct {
+	messages []string
+	fields   []logrus.Fields
+}
+
+func (f *memFormatter) Format(e *logrus.Entry) ([]byte, error) {
+	f.messages = append(f.messages, e.Message)
+	data := logrus.Fields{}
+	for k, v := range e.Data {
+		data[k] = v
+	}
+	f.fields = append(f.fields, data)
+	return nil, nil
+}
+
+func TestHook(t *testing.T) {
+	f := &memFormatter{}
+	l := logrus.New()
+	l.Out = ioutil.Discard
+	l.Formatter = f
+	l.Level = logrus.TraceLevel
+	l.AddHook(NewLogrusHook(creditcard.ScrubSkipKeys("raw")))
+
+	l.WithFields(logrus.Fields{
+		"pan":      "4111 1111 1111 1111",
+		"order_id": "1234567890123456",
+		"amount":   4111111111111111,
+		"raw":      "4111111111111111",
+	}).Warn("charge 5555555555554444 for order 1234567890123456")
+	l.Trace("card 4012888888881881")
+
+	expectMessages := []string{
+		"charge 555555******4444 for order 1234567890123456",
+		"card 401288******1881",
+	}
+	if !reflect.DeepEqual(expectMessages, f.messages) {
+		t.Log("expect:", expectMessages)
+		t.Log("actual:", f.messages)
+		t.Fatal("unexpected messages")
+	}
+
+	expectFields := logrus.Fields{
+		"pan":      "4111 11** **** 1111",
+		"order_id": "1234567890123456",
+		"amount":   4111111111111111,
+		"raw":      "4111111111111111",
+	}
+	if !reflect.DeepEqual(expectFields, f.fields[0]) {
+		t.Log("expect:", expectFields)
+		t.Log("actual:", f.fields[0])
+		t.Fatal("unexpected fields")
+	}
+}
@@ -0,0 +1,107 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+// ErrLuhnEmpty is returned by LuhnAccumulator.Pop if there's no digit
+const ErrLuhnEmpty ErrArgument = "no digit to pop"
+
+// luhnDouble doubles d and sums the digits of the result
+func luhnDouble(d int) (ret int) {
+	if d *= 2; d > 9 {
+		d -= 9
+	}
+	return d
+}
+
+// luhnSum computes luhn sum of ascii digits, the last digit is treated as
+// check digit
+func luhnSum(digits []byte) (ret int) {
+	double := false
+	for idx := len(digits) - 1; idx >= 0; idx-- {
+		d := int(digits[idx] - '0')
+		if double {
+			d = luhnDouble(d)
+		}
+		ret += d
+		double = !double
+	}
+	return
+}
+
+// luhnValid reports if ascii digits pass luhn check
+func luhnValid(digits []byte) (ret bool) {
+	return len(digits) > 0 && luhnSum(digits)%10 == 0
+}
+
+// LuhnAccumulator maintains luhn state of digits arriving one at a time
+//
+// Every operation is O(1). Digits are ascii digits ('0' to '9'). The zero
+// value is an empty accumulator ready to use. It is not safe for concurrent
+// use.
+type LuhnAccumulator struct {
+	digits []byte
+	// luhn sums of digits, sums[0] doubles digits at odd indexes and
+	// sums[1] doubles digits at even indexes
+	sums [2]int
+}
+
+// Push appends a digit, it returns ErrNotDigit if d is not a digit
+func (a *LuhnAccumulator) Push(d byte) (err error) {
+	if d < '0' || d > '9' {
+		return ErrNotDigit
+	}
+	a.add(len(a.digits), int(d-'0'), 1)
+	a.digits = append(a.digits, d)
+	return
+}
+
+// Pop removes last digit, it returns ErrLuhnEmpty if there's no digit
+func (a *LuhnAccumulator) Pop() (err error) {
+	l := len(a.digits) - 1
+	if l < 0 {
+		return ErrLuhnEmpty
+	}
+	a.add(l, int(a.digits[l]-'0'), -1)
+	a.digits = a.digits[:l]
+	return
+}
+
+func (a *LuhnAccumulator) add(idx, d, sign int) {
+	plain, doubled := d, luhnDouble(d)
+	if idx%2 == 1 {
+		plain, doubled = doubled, plain
+	}
+	a.sums[0] += sign * plain
+	a.sums[1] += sign * doubled
+}
+
+// Len returns number of digits
+func (a *LuhnAccumulator) Len() (ret int) {
+	return len(a.digits)
+}
+
+// Valid reports if digits pass luhn check, the last digit is treated as
+// check digit
+//
+// It returns false if there's no digit.
+func (a *LuhnAccumulator) Valid() (ret bool) {
+	l := len(a.digits)
+	// digits at same parity as the last one are not doubled
+	return l > 0 && a.sums[(l-1)%2]%10 == 0
+}
+
+// CheckDigit returns the ascii digit which makes digits pass luhn check if
+// appended
+func (a *LuhnAccumulator) CheckDigit() (ret byte) {
+	// the check digit would be at index len, same parity is not doubled
+	sum := a.sums[len(a.digits)%2]
+	return '0' + byte((10-sum%10)%10)
+}
+
+// Reset removes all digits
+func (a *LuhnAccumulator) Reset() {
+	a.digits = a.digits[:0]
+	a.sums = [2]int{}
+}
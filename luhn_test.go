@@ -0,0 +1,92 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import "testing"
+
+func TestLuhnAccumulator(t *testing.T) {
+	pans := []string{
+		"4111111111111111",
+		"5555555555554444",
+		"378282246310005",
+		"4111111111000066",
+		"0",
+		"18",
+	}
+
+	for _, pan := range pans {
+		t.Run(pan, func(t *testing.T) {
+			var a LuhnAccumulator
+			for idx := 0; idx < len(pan); idx++ {
+				if err := a.Push(pan[idx]); err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+				prefix := []byte(pan[:idx+1])
+				if actual, expect := a.Valid(), luhnValid(prefix); actual != expect {
+					t.Log("expect:", expect)
+					t.Log("actual:", actual)
+					t.Fatal("unexpected result at", idx)
+				}
+				d := a.CheckDigit()
+				if !luhnValid(append(prefix, d)) {
+					t.Fatal("unexpected check digit at", idx, string(d))
+				}
+			}
+			if !a.Valid() {
+				t.Fatal("expected valid")
+			}
+
+			// pop back to empty, state must match a fresh accumulator
+			for idx := len(pan) - 1; idx >= 0; idx-- {
+				if err := a.Pop(); err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+				var b LuhnAccumulator
+				for _, c := range []byte(pan[:idx]) {
+					b.Push(c)
+				}
+				if a.Valid() != b.Valid() || a.CheckDigit() != b.CheckDigit() || a.Len() != idx {
+					t.Fatal("unexpected state after pop at", idx)
+				}
+			}
+		})
+	}
+}
+
+func TestLuhnAccumulatorError(t *testing.T) {
+	var a LuhnAccumulator
+	if err := a.Pop(); err != ErrLuhnEmpty {
+		t.Log("expect:", ErrLuhnEmpty)
+		t.Log("actual:", err)
+		t.Fatal("unexpected error")
+	}
+	if a.Valid() {
+		t.Fatal("empty accumulator should not be valid")
+	}
+	if err := a.Push('x'); err != ErrNotDigit {
+		t.Log("expect:", ErrNotDigit)
+		t.Log("actual:", err)
+		t.Fatal("unexpected error")
+	}
+	if a.Len() != 0 {
+		t.Fatal("invalid digit is pushed")
+	}
+
+	for _, c := range []byte("4111111111111111") {
+		a.Push(c)
+	}
+	a.Push('2')
+	if a.Valid() {
+		t.Fatal("expected invalid")
+	}
+	a.Pop()
+	if !a.Valid() {
+		t.Fatal("expected valid after pop")
+	}
+	a.Reset()
+	if a.Len() != 0 || a.CheckDigit() != '0' {
+		t.Fatal("unexpected state after reset")
+	}
+}
@@ -0,0 +1,110 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"strings"
+	"sync"
+)
+
+// maskMatch reports if every known digit in masked equals to that in pan
+func maskMatch(masked, pan string) (ret bool) {
+	if len(masked) != len(pan) {
+		return false
+	}
+	for x := 0; x < len(masked); x++ {
+		if c := masked[x]; c != '*' && c != pan[x] {
+			return false
+		}
+	}
+	return true
+}
+
+// maskBucket identifies a bucket in MaskIndex, unknown part is empty
+type maskBucket struct {
+	length int
+	first6 string
+	last4  string
+}
+
+type maskRecord struct {
+	id  string
+	pan string
+}
+
+// MaskIndex finds stored masked PANs which might be a full PAN
+//
+// Records are bucketed by length, first 6 digits and last 4 digits, records
+// missing some of them are put into a broader bucket. Lookup checks only the
+// few buckets a full PAN could be in, then compares every known digit. It is
+// safe for concurrent use.
+type MaskIndex struct {
+	lock    sync.RWMutex
+	buckets map[maskBucket][]maskRecord
+}
+
+// NewMaskIndex creates an empty MaskIndex
+func NewMaskIndex() (ret *MaskIndex) {
+	return &MaskIndex{buckets: map[maskBucket][]maskRecord{}}
+}
+
+// Add adds a masked PAN identified by id, zero Info is ignored
+//
+// The PAN is stored as is, so do not add unmasked Infos unless the index
+// is handled like raw PANs.
+func (m *MaskIndex) Add(id string, masked Info) {
+	if masked.IsZero() {
+		return
+	}
+	pan := masked.RawPAN()
+	k := maskBucket{length: len(pan)}
+	if first6 := masked.First6(); !strings.Contains(first6, "*") {
+		k.first6 = first6
+	}
+	if last4 := masked.Last4(); !strings.Contains(last4, "*") {
+		k.last4 = last4
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.buckets[k] = append(m.buckets[k], maskRecord{id: id, pan: pan})
+}
+
+// Candidates returns ids of records which might be full, in insertion order
+// of each bucket
+func (m *MaskIndex) Candidates(full Info) (ret []string) {
+	if full.IsZero() {
+		return
+	}
+	pan := full.RawPAN()
+	l, first6, last4 := len(pan), full.First6(), full.Last4()
+	keys := [4]maskBucket{
+		{length: l, first6: first6, last4: last4},
+		{length: l, last4: last4},
+		{length: l, first6: first6},
+		{length: l},
+	}
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	for _, k := range keys {
+		for _, r := range m.buckets[k] {
+			if maskMatch(r.pan, pan) {
+				ret = append(ret, r.id)
+			}
+		}
+	}
+	return
+}
+
+// Len returns number of records
+func (m *MaskIndex) Len() (ret int) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	for _, b := range m.buckets {
+		ret += len(b)
+	}
+	return
+}
@@ -0,0 +1,120 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestMaskIndex(t *testing.T) {
+	records := map[string]string{
+		"first6last4": "411111******1111",
+		"first8last4": "41111111****1111",
+		"otherbin":    "422222******1111",
+		"otherlast4":  "411111******2222",
+		"last4only":   "************1111",
+		"nobin":       "4111********1111",
+		"middle":      "******1111******",
+		"exposed":     "411111111111111*",
+		"collision":   "411111******1111", // same bucket as first6last4
+		"other8":      "41111122****1111", // same bucket, different digits
+	}
+	idx := NewMaskIndex()
+	for id, pan := range records {
+		info, _ := FromRaw(pan)
+		idx.Add(id, info)
+	}
+	idx.Add("zero", NoCard)
+
+	if idx.Len() != len(records) {
+		t.Fatal("unexpected length:", idx.Len())
+	}
+
+	cases := map[string][]string{
+		"4111111111111111": {"collision", "exposed", "first6last4", "first8last4", "last4only", "middle", "nobin"},
+		"4111112211111111": {"collision", "first6last4", "last4only", "nobin", "other8"},
+		"4111112222222222": {"otherlast4"},
+		"5555555555554444": nil,
+	}
+	for pan, expect := range cases {
+		t.Run(pan, func(t *testing.T) {
+			info, _ := FromRaw(pan)
+			actual := idx.Candidates(info)
+			sort.Strings(actual)
+			if !reflect.DeepEqual(actual, expect) {
+				t.Log("expect:", expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+		})
+	}
+}
+
+func TestMaskIndexConcurrent(t *testing.T) {
+	idx := NewMaskIndex()
+	full, _ := FromRaw("4111111111111111")
+	wg := &sync.WaitGroup{}
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func(g int) {
+			defer wg.Done()
+			for x := 0; x < 200; x++ {
+				info, _ := FromMasked("411111", fmt.Sprintf("%04d", x%3+1110))
+				idx.Add(fmt.Sprint(g, x), info)
+			}
+		}(g)
+		go func() {
+			defer wg.Done()
+			for x := 0; x < 200; x++ {
+				idx.Candidates(full)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := len(idx.Candidates(full)); n != 4*67 {
+		t.Fatal("unexpected number of candidates:", n)
+	}
+}
+
+func maskIndexBenchData(n int) (masked []Info, full Info) {
+	masked = make([]Info, n)
+	for x := range masked {
+		masked[x], _ = FromMasked(fmt.Sprintf("4%05d", x%1000), fmt.Sprintf("%04d", x%10000))
+	}
+	full, _ = FromRaw("4000421111110042")
+	return
+}
+
+func BenchmarkMaskIndex(b *testing.B) {
+	masked, full := maskIndexBenchData(100000)
+	idx := NewMaskIndex()
+	for x, m := range masked {
+		idx.Add(fmt.Sprint(x), m)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		idx.Candidates(full)
+	}
+}
+
+func BenchmarkMaskIndexLinear(b *testing.B) {
+	masked, full := maskIndexBenchData(100000)
+	pans := make([]string, len(masked))
+	for x, m := range masked {
+		pans[x] = m.RawPAN()
+	}
+	pan := full.RawPAN()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, m := range pans {
+			maskMatch(m, pan)
+		}
+	}
+}
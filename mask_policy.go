@@ -0,0 +1,79 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ErrUnmasked is returned by ValidateMaskFormat if the PAN is not masked at all
+const ErrUnmasked ErrPANFormat = "pan is not masked"
+
+// MaskPolicy is an approved truncation format, which allows at most First
+// leading digits and Last trailing digits to be exposed
+type MaskPolicy struct {
+	First int
+	Last  int
+}
+
+// Approved truncation formats
+var (
+	MaskFirst6Last4 = MaskPolicy{First: 6, Last: 4}
+	MaskFirst8Last4 = MaskPolicy{First: 8, Last: 4}
+	MaskLast4       = MaskPolicy{First: 0, Last: 4}
+)
+
+// violations returns positions of exposed digits in pan not allowed by p
+func (p MaskPolicy) violations(pan string) (ret []int) {
+	for idx := p.First; idx < len(pan)-p.Last; idx++ {
+		if pan[idx] != '*' {
+			ret = append(ret, idx)
+		}
+	}
+	return
+}
+
+// ErrMaskFormat is returned by ValidateMaskFormat if a masked PAN does not
+// follow any of the policies
+type ErrMaskFormat struct {
+	// positions of exposed digits violating the closest policy, starts from 0
+	Positions []int
+}
+
+func (e ErrMaskFormat) Error() (ret string) {
+	pos := make([]string, len(e.Positions))
+	for idx, p := range e.Positions {
+		pos[idx] = strconv.Itoa(p)
+	}
+	return "creditcard: incorrect pan format: digits at position " +
+		strings.Join(pos, ",") + " should be masked"
+}
+
+func (i *info) ValidateMaskFormat(policies ...MaskPolicy) (err error) {
+	if i.IsZero() {
+		return ErrNoCard
+	}
+	pan := i.RawPAN()
+	if !strings.Contains(pan, "*") {
+		return ErrUnmasked
+	}
+	if len(policies) == 0 {
+		policies = []MaskPolicy{MaskFirst6Last4, MaskFirst8Last4, MaskLast4}
+	}
+
+	var closest []int
+	for idx, p := range policies {
+		v := p.violations(pan)
+		if len(v) == 0 {
+			return nil
+		}
+		if idx == 0 || len(v) < len(closest) {
+			closest = v
+		}
+	}
+
+	return ErrMaskFormat{Positions: closest}
+}
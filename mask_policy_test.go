@@ -0,0 +1,53 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateMaskFormat(t *testing.T) {
+	cases := []struct {
+		pan      string
+		policies []MaskPolicy
+		err      error
+	}{
+		{"411111******1111", nil, nil},
+		{"41111111****1111", nil, nil},
+		{"************1111", nil, nil},
+		{"****************", nil, nil},
+		{"411111******1111", []MaskPolicy{MaskFirst6Last4}, nil},
+		{"411111******1111", []MaskPolicy{MaskFirst8Last4}, nil},
+		{"411111******1111", []MaskPolicy{MaskLast4}, ErrMaskFormat{Positions: []int{0, 1, 2, 3, 4, 5}}},
+		{"41111111****1111", []MaskPolicy{MaskFirst6Last4}, ErrMaskFormat{Positions: []int{6, 7}}},
+		{"4111111111**1111", nil, ErrMaskFormat{Positions: []int{8, 9}}},
+		{"4111111111**1111", []MaskPolicy{MaskLast4, MaskFirst6Last4}, ErrMaskFormat{Positions: []int{6, 7, 8, 9}}},
+		{"******1111******", nil, ErrMaskFormat{Positions: []int{8, 9}}},
+		{"*******1********", []MaskPolicy{MaskFirst6Last4}, ErrMaskFormat{Positions: []int{7}}},
+		{"4111111111111111", nil, ErrUnmasked},
+	}
+
+	for _, c := range cases {
+		t.Run(c.pan, func(t *testing.T) {
+			info, _ := FromRaw(c.pan)
+			actual := info.ValidateMaskFormat(c.policies...)
+			if !reflect.DeepEqual(actual, c.err) {
+				t.Log("expect:", c.err)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+		})
+	}
+}
+
+func TestErrMaskFormat(t *testing.T) {
+	expect := "creditcard: incorrect pan format: digits at position 6,7 should be masked"
+	if actual := (ErrMaskFormat{Positions: []int{6, 7}}).Error(); actual != expect {
+		t.Log("expect:", expect)
+		t.Log("actual:", actual)
+		t.Fatal("unexpected result")
+	}
+}
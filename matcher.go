@@ -0,0 +1,181 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrMatcherName is returned by RegisterMatcher if name is empty or taken,
+// including names of built-in card types, see ParseCardType
+const ErrMatcherName ErrArgument = "matcher name must be unique and non-empty"
+
+// CardTypes minted by RegisterMatcher start from here, so they do not
+// collide with built-in ones
+const firstRegisteredCardType CardType = 1000
+
+type matcher struct {
+	name     string
+	priority int
+	match    func(prefix string) bool
+	typ      CardType
+}
+
+// names of card types minted by RegisterMatcher, shared by every Config so
+// CardType.String and ParseCardType work regardless of which Config detects
+// them
+var (
+	typeLock        sync.Mutex   // serializes mintCardType
+	registeredNames atomic.Value // []string, indexed by CardType - firstRegisteredCardType
+)
+
+func init() {
+	registeredNames.Store([]string(nil))
+}
+
+func currentNames() (ret []string) {
+	return registeredNames.Load().([]string)
+}
+
+// mintCardType returns the CardType of name, a new one is minted if name is
+// not seen before
+func mintCardType(name string) (ret CardType) {
+	typeLock.Lock()
+	defer typeLock.Unlock()
+	cur := currentNames()
+	for idx, n := range cur {
+		if n == name {
+			return firstRegisteredCardType + CardType(idx)
+		}
+	}
+
+	list := make([]string, len(cur), len(cur)+1)
+	copy(list, cur)
+	registeredNames.Store(append(list, name))
+	return firstRegisteredCardType + CardType(len(cur))
+}
+
+// registeredTypes returns CardTypes minted by RegisterMatcher of any Config,
+// ordered by their values
+func registeredTypes() (ret []CardType) {
+	ret = make([]CardType, len(currentNames()))
+	for idx := range ret {
+		ret[idx] = firstRegisteredCardType + CardType(idx)
+	}
+	return
+}
+
+// WithMatcher registers a matcher to the Config, see Config.RegisterMatcher
+//
+// It panics if name is empty or used twice in same Config. The card type can
+// be found by ParseCardType(name).
+func WithMatcher(name string, priority int, match func(prefix string) bool) (ret Option) {
+	return func(c *Config) {
+		if _, err := c.RegisterMatcher(name, priority, match); err != nil {
+			panic("creditcard: cannot register matcher " + name + ": " + err.Error())
+		}
+	}
+}
+
+// RegisterMatcher registers a card type detected by a predicate to the
+// default Config, see Config.RegisterMatcher
+//
+// It affects package-level functions and Infos created by them only, Configs
+// created by New are not affected.
+func RegisterMatcher(name string, priority int, match func(prefix string) bool) (ret CardType, err error) {
+	return defaultConfig.RegisterMatcher(name, priority, match)
+}
+
+// RegisterMatcher registers a card type detected by a predicate, for schemes
+// which cannot be expressed as prefix ranges, like closed-loop gift cards
+//
+// match receives the longest known leading digits of the PAN, which may be
+// only few digits if the PAN is masked. Matchers with positive priority are
+// evaluated before built-in prefix ranges, others only if no built-in range
+// matches. Higher priority is evaluated first, matchers with same priority
+// are evaluated in registration order.
+//
+// name must not be taken by built-in card types, like "visa", "Master Card"
+// or "unknown", so String and ParseCardType are consistent.
+//
+// match must be fast and safe for concurrent use. If it panics, the PAN is
+// classified as UnknownCardType. The matcher is used by c only, but the
+// returned CardType is shared by name in the process: matchers of same name
+// in different Configs get same CardType. It is assigned in order of first
+// registration, do not persist it. It is safe for concurrent use, but PANs
+// parsed before registration keep their card type.
+func (c *Config) RegisterMatcher(name string, priority int, match func(prefix string) bool) (ret CardType, err error) {
+	if name == "" || match == nil || builtinName(name) {
+		return UnknownCardType, ErrMatcherName
+	}
+
+	c.matcherLock.Lock()
+	defer c.matcherLock.Unlock()
+	cur := c.currentMatchers()
+	for _, m := range cur {
+		if m.name == name {
+			return UnknownCardType, ErrMatcherName
+		}
+	}
+
+	ret = mintCardType(name)
+	list := make([]matcher, len(cur), len(cur)+1)
+	copy(list, cur)
+	list = append(list, matcher{name: name, priority: priority, match: match, typ: ret})
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].priority > list[j].priority
+	})
+	c.matchers.Store(list)
+	return
+}
+
+// builtinName reports if ParseCardType parses name as a built-in card type,
+// including UnknownCardType
+func builtinName(name string) (ret bool) {
+	key := typeKey(name)
+	if key == "" || key == "unknown" {
+		return true
+	}
+	if _, ok := typeAliases[key]; ok {
+		return true
+	}
+	for _, b := range brands {
+		if typeKey(b.name) == key {
+			return true
+		}
+	}
+	return
+}
+
+func (c *Config) currentMatchers() (ret []matcher) {
+	return c.matchers.Load().([]matcher)
+}
+
+// knownPrefix returns leading digits of pan until first masked digit
+func knownPrefix(pan string) (ret string) {
+	if idx := strings.IndexByte(pan, '*'); idx >= 0 {
+		return pan[:idx]
+	}
+	return pan
+}
+
+// runMatchers returns card type of first matched matcher in list
+func runMatchers(list []matcher, prefix string) (ret CardType, ok bool) {
+	defer func() {
+		if recover() != nil {
+			ret, ok = UnknownCardType, true
+		}
+	}()
+
+	for _, m := range list {
+		if m.match(prefix) {
+			return m.typ, true
+		}
+	}
+	return UnknownCardType, false
+}
@@ -0,0 +1,153 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// restoreMatchers unregisters matchers and card types registered after it is
+// called
+func restoreMatchers() (ret func()) {
+	orig, names := defaultConfig.currentMatchers(), currentNames()
+	return func() {
+		defaultConfig.matchers.Store(orig)
+		registeredNames.Store(names)
+	}
+}
+
+func mustRegister(t *testing.T, name string, priority int, match func(string) bool) (ret CardType) {
+	ret, err := RegisterMatcher(name, priority, match)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	return
+}
+
+func TestRegisterMatcher(t *testing.T) {
+	defer restoreMatchers()()
+
+	// BIN 789xxx where digit 7 is even
+	gift := mustRegister(t, "gift", 0, func(prefix string) bool {
+		return len(prefix) >= 7 && strings.HasPrefix(prefix, "789") && (prefix[6]-'0')%2 == 0
+	})
+	fleet := mustRegister(t, "fleet", 10, func(prefix string) bool {
+		return strings.HasPrefix(prefix, "4000")
+	})
+	mustRegister(t, "shadow", -1, func(prefix string) bool {
+		return strings.HasPrefix(prefix, "4")
+	})
+	mustRegister(t, "broken", 5, func(prefix string) bool {
+		if strings.HasPrefix(prefix, "9") {
+			panic("boom")
+		}
+		return false
+	})
+
+	if _, err := RegisterMatcher("gift", 0, func(string) bool { return false }); err != ErrMatcherName {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, err := RegisterMatcher("", 0, func(string) bool { return false }); err != ErrMatcherName {
+		t.Fatal("unexpected error:", err)
+	}
+	// names of built-in types, aliases and unknown
+	for _, name := range []string{"visa", "Visa Electron", "t-union", "master", "American Express", "CUP", "unknown", "Unknown", " - "} {
+		if _, err := RegisterMatcher(name, 0, func(string) bool { return false }); err != ErrMatcherName {
+			t.Fatal("unexpected error of", name, err)
+		}
+	}
+
+	cases := []struct {
+		pan    string
+		expect CardType
+	}{
+		{"7891230111111111", gift},
+		{"7891231111111111", UnknownCardType},
+		{"789123******1111", UnknownCardType}, // digit 7 is masked
+		{"4000001111111111", fleet},
+		{"4111111111111111", VISACard},
+		{"5555555555554444", MasterCard},
+		{"9111111111111111", UnknownCardType},
+	}
+	for _, c := range cases {
+		t.Run(c.pan, func(t *testing.T) {
+			info, err := Parse(c.pan)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if actual := info.CardType(); actual != c.expect {
+				t.Log("expect:", c.expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+		})
+	}
+
+	all := AllCardTypes()
+	expect := []CardType{gift, fleet, gift + 2, gift + 3}
+	if actual := all[len(all)-4:]; !reflect.DeepEqual(actual, expect) {
+		t.Log("expect:", expect)
+		t.Log("actual:", actual)
+		t.Fatal("unexpected registered types")
+	}
+	if !gift.Known() || (gift + 4).Known() {
+		t.Fatal("unexpected result of Known")
+	}
+}
+
+func TestConfigMatcher(t *testing.T) {
+	defer restoreMatchers()()
+
+	fleet := New(WithMatcher("fleet", 10, func(prefix string) bool {
+		return strings.HasPrefix(prefix, "4000")
+	}))
+	gift := New(WithMatcher("gift", 10, func(prefix string) bool {
+		return strings.HasPrefix(prefix, "4000")
+	}))
+	fleetType, _ := ParseCardType("fleet")
+	giftType, _ := ParseCardType("gift")
+
+	cases := []struct {
+		cfg    *Config
+		expect CardType
+	}{
+		{fleet, fleetType},
+		{gift, giftType},
+		{defaultConfig, VISACard},
+		{New(), VISACard},
+	}
+	for _, c := range cases {
+		info, err := c.cfg.FromRaw("4000001111111111")
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if actual := info.CardType(); actual != c.expect {
+			t.Log("expect:", c.expect)
+			t.Log("actual:", actual)
+			t.Fatal("unexpected result")
+		}
+	}
+	if fleetType == giftType || !fleetType.Known() || fleetType.String() != "fleet" {
+		t.Fatal("unexpected card types:", int(fleetType), int(giftType))
+	}
+
+	// same name gets same card type in different Configs
+	typ, err := New().RegisterMatcher("fleet", 0, func(string) bool { return false })
+	if err != nil || typ != fleetType {
+		t.Fatal("unexpected result:", typ, err)
+	}
+	if _, err = fleet.RegisterMatcher("fleet", 0, func(string) bool { return false }); err != ErrMatcherName {
+		t.Fatal("unexpected error:", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic of invalid matcher")
+		}
+	}()
+	New(WithMatcher("", 0, nil))
+}
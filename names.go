@@ -0,0 +1,118 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// String returns a stable identifier of t, like "visa" or "amex"
+//
+// Card types registered by RegisterMatcher return the name of the matcher.
+// UnknownCardType and other values return "unknown".
+func (t CardType) String() (ret string) {
+	if b, ok := brands[t]; ok {
+		return b.name
+	}
+	if t >= firstRegisteredCardType && t.Known() {
+		return currentNames()[t-firstRegisteredCardType]
+	}
+	return "unknown"
+}
+
+// other names accepted by ParseCardType, in the form of typeKey
+var typeAliases = map[string]CardType{
+	"master":          MasterCard,
+	"americanexpress": AmericanExpress,
+	"cup":             UnionPay,
+	"chinaunionpay":   UnionPay,
+	"dinersclub":      DinersClub,
+	"cb":              CarteBancaire,
+	"cartebancaire":   CarteBancaire,
+	"chinatunion":     ChinaTUnion,
+}
+
+// ErrCardType is reported by errors.Is for errors of unknown card type names
+const ErrCardType ErrArgument = "unknown card type name"
+
+// ErrCardTypeName is returned by ParseCardType and CardType.UnmarshalText if
+// the name is not known
+//
+// errors.Is(err, ErrCardType) is true for it.
+type ErrCardTypeName struct {
+	Name string // the name as given
+}
+
+func (e *ErrCardTypeName) Error() (ret string) {
+	return ErrCardType.Error() + ": " + strconv.Quote(e.Name)
+}
+
+// Is reports if target is ErrCardType
+func (e *ErrCardTypeName) Is(target error) (ret bool) {
+	return target == ErrCardType
+}
+
+// typeKey converts name to lower case, and removes spaces, hyphens and
+// underscores
+func typeKey(name string) (ret string) {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '-', '_':
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, name)
+}
+
+// ParseCardType finds the card type of name
+//
+// It accepts names returned by CardType.String and common synonyms like
+// "master", "american express" or "china unionpay". Case, spaces, hyphens
+// and underscores are ignored, so "Visa Electron" is same as
+// "visa-electron". "unknown" is UnknownCardType, so every value returned by
+// String can be parsed back.
+//
+// An *ErrCardTypeName is returned if name is not known.
+func ParseCardType(name string) (ret CardType, err error) {
+	key := typeKey(name)
+	if key == "unknown" {
+		return UnknownCardType, nil
+	}
+	for t, b := range brands {
+		if typeKey(b.name) == key {
+			return t, nil
+		}
+	}
+	if t, ok := typeAliases[key]; ok {
+		return t, nil
+	}
+	for idx, n := range currentNames() {
+		if typeKey(n) == key {
+			return firstRegisteredCardType + CardType(idx), nil
+		}
+	}
+	return UnknownCardType, &ErrCardTypeName{Name: name}
+}
+
+// MarshalText implements encoding.TextMarshaler, it returns same value as
+// String
+func (t CardType) MarshalText() (ret []byte, err error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+//
+// It accepts same names as ParseCardType. t is not modified if text is not
+// known.
+func (t *CardType) UnmarshalText(text []byte) (err error) {
+	v, err := ParseCardType(string(text))
+	if err != nil {
+		return
+	}
+	*t = v
+	return
+}
@@ -0,0 +1,239 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCardTypeString(t *testing.T) {
+	cases := []struct {
+		typ    CardType
+		expect string
+	}{
+		{VISACard, "visa"},
+		{MasterCard, "mastercard"},
+		{JCBCard, "jcb"},
+		{AmericanExpress, "amex"},
+		{UnionPay, "unionpay"},
+		{DinersClub, "diners"},
+		{MaestroCard, "maestro"},
+		{MirCard, "mir"},
+		{RuPayCard, "rupay"},
+		{EloCard, "elo"},
+		{HipercardCard, "hipercard"},
+		{DankortCard, "dankort"},
+		{TroyCard, "troy"},
+		{VerveCard, "verve"},
+		{UATPCard, "uatp"},
+		{InstaPaymentCard, "instapayment"},
+		{VisaElectron, "visa-electron"},
+		{CarteBancaire, "cartes-bancaires"},
+		{Forbrugsforeningen, "forbrugsforeningen"},
+		{UzCard, "uzcard"},
+		{HumoCard, "humo"},
+		{NapasCard, "napas"},
+		{ChinaTUnion, "t-union"},
+		{UnknownCardType, "unknown"},
+		{beginKnownCardType, "unknown"},
+		{endKnownCardType, "unknown"},
+		{-100, "unknown"},
+		{100, "unknown"},
+		{firstRegisteredCardType, "unknown"},
+	}
+	for _, c := range cases {
+		if actual := c.typ.String(); actual != c.expect {
+			t.Log("expect:", c.expect)
+			t.Log("actual:", actual)
+			t.Fatalf("unexpected result of %d", int(c.typ))
+		}
+	}
+	if actual := fmt.Sprint(AmericanExpress); actual != "amex" {
+		t.Fatal("unexpected result of fmt:", actual)
+	}
+
+	// every card type has a distinct name
+	seen := map[string]bool{}
+	for _, typ := range AllCardTypes() {
+		name := typ.String()
+		if name == "unknown" || seen[name] {
+			t.Fatalf("unexpected name of %d: %s", int(typ), name)
+		}
+		seen[name] = true
+	}
+}
+
+func TestCardTypeStringRegistered(t *testing.T) {
+	defer restoreMatchers()()
+
+	gift := mustRegister(t, "gift", 0, func(prefix string) bool { return false })
+	if actual := gift.String(); actual != "gift" {
+		t.Fatal("unexpected result:", actual)
+	}
+	if actual := (gift + 1).String(); actual != "unknown" {
+		t.Fatal("unexpected result:", actual)
+	}
+}
+
+func TestCardTypeText(t *testing.T) {
+	for _, typ := range append(AllCardTypes(), UnknownCardType) {
+		buf, err := typ.MarshalText()
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		var actual CardType
+		if err = actual.UnmarshalText(buf); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if actual != typ {
+			t.Log("expect:", typ)
+			t.Log("actual:", actual)
+			t.Fatal("unexpected result of", string(buf))
+		}
+	}
+
+	aliases := map[string]CardType{
+		"VISA":             VISACard,
+		"Amex":             AmericanExpress,
+		"master":           MasterCard,
+		"Master-Card":      MasterCard,
+		"MASTERCARD":       MasterCard,
+		"american-express": AmericanExpress,
+		"American_Express": AmericanExpress,
+		"cup":              UnionPay,
+		"CUP":              UnionPay,
+		"Unknown":          UnknownCardType,
+	}
+	for name, expect := range aliases {
+		var actual CardType
+		if err := actual.UnmarshalText([]byte(name)); err != nil {
+			t.Fatal("unexpected error of", name, err)
+		}
+		if actual != expect {
+			t.Log("expect:", expect)
+			t.Log("actual:", actual)
+			t.Fatal("unexpected result of", name)
+		}
+	}
+
+	for _, name := range []string{"", "discover", "2"} {
+		actual := VISACard
+		err := actual.UnmarshalText([]byte(name))
+		if !errors.Is(err, ErrCardType) {
+			t.Fatal("unexpected error of", name, err)
+		}
+		if e, ok := err.(*ErrCardTypeName); !ok || e.Name != name {
+			t.Fatal("unexpected error of", name, err)
+		}
+		if actual != VISACard {
+			t.Fatal("card type should not be modified on error:", actual)
+		}
+	}
+}
+
+func TestCardTypeJSON(t *testing.T) {
+	type payment struct {
+		Brand  CardType         `json:"brand"`
+		Counts map[CardType]int `json:"counts"`
+	}
+	data := payment{
+		Brand:  VISACard,
+		Counts: map[CardType]int{AmericanExpress: 1, UnknownCardType: 2},
+	}
+	buf, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	expect := `{"brand":"visa","counts":{"amex":1,"unknown":2}}`
+	if string(buf) != expect {
+		t.Log("expect:", expect)
+		t.Log("actual:", string(buf))
+		t.Fatal("unexpected json")
+	}
+
+	var actual payment
+	if err = json.Unmarshal([]byte(`{"brand":"Master-Card","counts":{"cup":3}}`), &actual); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if actual.Brand != MasterCard || len(actual.Counts) != 1 || actual.Counts[UnionPay] != 3 {
+		t.Fatal("unexpected result:", actual)
+	}
+
+	if err = json.Unmarshal([]byte(`{"brand":"discover"}`), &actual); !errors.Is(err, ErrCardType) {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestParseCardType(t *testing.T) {
+	// every name can be parsed back
+	for _, typ := range append(AllCardTypes(), UnknownCardType) {
+		actual, err := ParseCardType(typ.String())
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if actual != typ {
+			t.Log("expect:", typ)
+			t.Log("actual:", actual)
+			t.Fatal("unexpected result of", typ.String())
+		}
+	}
+
+	cases := map[string]CardType{
+		"visa":             VISACard,
+		" Visa ":           VISACard,
+		"JCB":              JCBCard,
+		"amex":             AmericanExpress,
+		"americanexpress":  AmericanExpress,
+		"American Express": AmericanExpress,
+		"american-express": AmericanExpress,
+		"Master Card":      MasterCard,
+		"master":           MasterCard,
+		"cup":              UnionPay,
+		"China UnionPay":   UnionPay,
+		"union_pay":        UnionPay,
+		"Diners Club":      DinersClub,
+		"Visa Electron":    VisaElectron,
+		"visaelectron":     VisaElectron,
+		"Carte Bancaire":   CarteBancaire,
+		"CB":               CarteBancaire,
+		"China T-Union":    ChinaTUnion,
+		"T Union":          ChinaTUnion,
+	}
+	for name, expect := range cases {
+		actual, err := ParseCardType(name)
+		if err != nil {
+			t.Fatal("unexpected error of", name, err)
+		}
+		if actual != expect {
+			t.Log("expect:", expect)
+			t.Log("actual:", actual)
+			t.Fatal("unexpected result of", name)
+		}
+	}
+
+	for _, name := range []string{"", " - ", "discover", "visa2", "4"} {
+		actual, err := ParseCardType(name)
+		if actual != UnknownCardType || !errors.Is(err, ErrCardType) {
+			t.Fatal("unexpected result of", name, actual, err)
+		}
+		if e, ok := err.(*ErrCardTypeName); !ok || e.Name != name {
+			t.Fatal("unexpected error of", name, err)
+		}
+	}
+}
+
+func TestParseCardTypeRegistered(t *testing.T) {
+	defer restoreMatchers()()
+
+	gift := mustRegister(t, "Gift Card", 0, func(prefix string) bool { return false })
+	for _, name := range []string{"Gift Card", "gift-card", "giftcard"} {
+		if actual, err := ParseCardType(name); err != nil || actual != gift {
+			t.Fatal("unexpected result of", name, actual, err)
+		}
+	}
+}
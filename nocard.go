@@ -0,0 +1,103 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import "strings"
+
+// ErrNoCard is returned by operations on NoCard
+const ErrNoCard ErrPANFormat = "no card"
+
+// noCard is the implementation of NoCard
+type noCard struct{}
+
+// NoCard represents absence of a card, see ParseOptional
+//
+// Methods returning string return empty string, CardType returns
+// UnknownCardType, and methods returning error return ErrNoCard.
+var NoCard Info = noCard{}
+
+func (noCard) CardType() (ret CardType)  { return UnknownCardType }
+func (noCard) Checksum() (ret string)    { return }
+func (noCard) Last4() (ret string)       { return }
+func (noCard) First6() (ret string)      { return }
+func (noCard) FullLast4() (ret string)   { return }
+func (noCard) FullFirst6() (ret string)  { return }
+func (noCard) RawMasked() (ret string)   { return }
+func (noCard) Masked() (ret string)      { return }
+func (noCard) RawPAN() (ret string)      { return }
+func (noCard) PAN() (ret string)         { return }
+func (noCard) Canonical() (ret string)   { return }
+func (noCard) AuditString() (ret string) { return }
+func (noCard) Validate() (err error)     { return ErrNoCard }
+func (noCard) IsZero() (ret bool)        { return true }
+func (noCard) IsFullyMasked() (ret bool) { return false }
+func (noCard) Corrections() (ret int)    { return 0 }
+
+func (noCard) ShardKey(n int, key []byte) (ret int, err error) {
+	return 0, ErrNoCard
+}
+
+func (noCard) Anonymize(key []byte) (ret Info, err error) {
+	return nil, ErrNoCard
+}
+
+func (noCard) ValidateMaskFormat(policies ...MaskPolicy) (err error) {
+	return ErrNoCard
+}
+
+func (noCard) WithSequence(n int) (ret Info, err error) {
+	return nil, ErrNoCard
+}
+
+func (noCard) WithSection(index int, value string) (ret Info, err error) {
+	return nil, ErrNoCard
+}
+
+func (noCard) FormatTemplate(tmpl string) (ret string, err error) {
+	return "", ErrNoCard
+}
+
+func (noCard) Sequence() (ret int, ok bool) {
+	return
+}
+
+func (noCard) WithIssueNumber(n int) (ret Info, err error) {
+	return nil, ErrNoCard
+}
+
+func (noCard) IssueNumber() (ret int, ok bool) {
+	return
+}
+
+func (noCard) EnumerateCompletions(limit int) (ret []Info, err error) {
+	return nil, ErrNoCard
+}
+
+func (noCard) EncodeUint64() (ret uint64, err error) {
+	return 0, ErrNoCard
+}
+
+func (noCard) Pseudonym(key []byte) (ret string, err error) {
+	return "", ErrNoCard
+}
+
+// ParseOptional is like Parse, but empty or whitespace-only input is treated
+// as absence of a card
+//
+// It returns NoCard and false for such input, or result of Parse and true for
+// everything else.
+func ParseOptional(str string) (ret Info, ok bool, err error) {
+	return defaultConfig.ParseOptional(str)
+}
+
+// ParseOptional is same as package-level ParseOptional, but uses c
+func (c *Config) ParseOptional(str string) (ret Info, ok bool, err error) {
+	if strings.TrimSpace(str) == "" {
+		return NoCard, false, nil
+	}
+
+	ret, err = c.Parse(str)
+	return ret, true, err
+}
@@ -0,0 +1,164 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestNoCard(t *testing.T) {
+	strs := map[string]func() string{
+		"Checksum":    NoCard.Checksum,
+		"Last4":       NoCard.Last4,
+		"First6":      NoCard.First6,
+		"FullLast4":   NoCard.FullLast4,
+		"FullFirst6":  NoCard.FullFirst6,
+		"RawMasked":   NoCard.RawMasked,
+		"Masked":      NoCard.Masked,
+		"RawPAN":      NoCard.RawPAN,
+		"PAN":         NoCard.PAN,
+		"Canonical":   NoCard.Canonical,
+		"AuditString": NoCard.AuditString,
+	}
+	for name, f := range strs {
+		t.Run(name, func(t *testing.T) {
+			if actual := f(); actual != "" {
+				t.Log("expect: empty string")
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+		})
+	}
+
+	if actual := NoCard.CardType(); actual != UnknownCardType {
+		t.Log("expect:", UnknownCardType)
+		t.Log("actual:", actual)
+		t.Fatal("unexpected card type")
+	}
+
+	key := []byte("key")
+	_, shardErr := NoCard.ShardKey(16, key)
+	_, anonErr := NoCard.Anonymize(key)
+	_, pseudoErr := NoCard.Pseudonym(key)
+	errs := map[string]error{
+		"Validate":  NoCard.Validate(),
+		"ShardKey":  shardErr,
+		"Anonymize": anonErr,
+		"Pseudonym": pseudoErr,
+	}
+	for name, err := range errs {
+		if err != ErrNoCard {
+			t.Log("expect:", ErrNoCard)
+			t.Log("actual:", err)
+			t.Fatal("unexpected error from", name)
+		}
+	}
+}
+
+func TestParseOptional(t *testing.T) {
+	for _, empty := range []string{"", " ", "\t\n"} {
+		info, ok, err := ParseOptional(empty)
+		if info != NoCard || ok || err != nil {
+			t.Fatalf("unexpected result for %q: %v %v %v", empty, info, ok, err)
+		}
+	}
+
+	info, ok, err := ParseOptional("4111-1111-1111-1111")
+	if !ok || err != nil || info.RawPAN() != "4111111111111111" {
+		t.Fatal("unexpected result:", ok, err)
+	}
+
+	if _, ok, err = ParseOptional("4111"); !ok || err == nil {
+		t.Fatal("expected error for malformed input")
+	}
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// callAll calls every method of Info on info, returns results by method name
+func callAll(info Info) (ret map[string][]reflect.Value) {
+	ret = map[string][]reflect.Value{}
+	v := reflect.ValueOf(info)
+	typ := reflect.TypeOf((*Info)(nil)).Elem()
+	for x := 0; x < typ.NumMethod(); x++ {
+		m := typ.Method(x)
+		n := m.Type.NumIn()
+		if m.Type.IsVariadic() {
+			n--
+		}
+		args := make([]reflect.Value, 0, n)
+		for a := 0; a < n; a++ {
+			switch in := m.Type.In(a); {
+			case in.Kind() == reflect.Int:
+				args = append(args, reflect.ValueOf(16))
+			case in == reflect.TypeOf([]byte(nil)):
+				args = append(args, reflect.ValueOf([]byte("key")))
+			default:
+				args = append(args, reflect.Zero(in))
+			}
+		}
+		ret[m.Name] = v.MethodByName(m.Name).Call(args)
+	}
+	return
+}
+
+func TestZeroInfo(t *testing.T) {
+	zeros := map[string]Info{
+		"zero":   &info{},
+		"nil":    (*info)(nil),
+		"nocard": NoCard,
+	}
+
+	for name, z := range zeros {
+		t.Run(name, func(t *testing.T) {
+			if !z.IsZero() {
+				t.Fatal("IsZero returns false")
+			}
+
+			wg := &sync.WaitGroup{}
+			results := make([]map[string][]reflect.Value, 4)
+			for x := range results {
+				wg.Add(1)
+				go func(x int) {
+					defer wg.Done()
+					results[x] = callAll(z)
+				}(x)
+			}
+			wg.Wait()
+
+			for method, outs := range results[0] {
+				for _, out := range outs {
+					switch {
+					case out.Type() == errorType:
+						if err, _ := out.Interface().(error); err != ErrNoCard {
+							t.Fatalf("%s returns error %v", method, err)
+						}
+					case out.Kind() == reflect.String:
+						if out.String() != "" {
+							t.Fatalf("%s returns %q", method, out.String())
+						}
+					case out.Type() == reflect.TypeOf(UnknownCardType):
+						if out.Interface() != UnknownCardType {
+							t.Fatalf("%s returns %v", method, out.Interface())
+						}
+					}
+				}
+			}
+		})
+	}
+
+	if _, err := Fingerprint(&info{}, []byte("key")); err != ErrNoCard {
+		t.Log("expect:", ErrNoCard)
+		t.Log("actual:", err)
+		t.Fatal("unexpected error")
+	}
+
+	full, _ := FromRaw("4111111111111111")
+	if full.IsZero() {
+		t.Fatal("IsZero returns true for a full PAN")
+	}
+}
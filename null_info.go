@@ -0,0 +1,94 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+)
+
+// ErrNullInfoType is returned by NullInfo.Scan if the value cannot be
+// converted to string
+const ErrNullInfoType ErrArgument = "unsupported type for NullInfo"
+
+// NullInfo is an Info which may be null, for nullable database columns and
+// optional JSON fields
+//
+// Info is stored in canonical form (see Info.Canonical), which is the raw
+// PAN if it is not masked. Store a masked Info, like one created by
+// FromMasked(info.First6(), info.Last4()), if the database or JSON must not
+// contain full PAN. Sequence and issue numbers (see Info.WithSequence and
+// Info.WithIssueNumber) are not part of it, so they are lost. Empty string is
+// an error unless EmptyIsNull is set.
+type NullInfo struct {
+	Info  Info // NoCard if NULL after Scan or UnmarshalJSON
+	Valid bool // Valid is true if Info is not NULL
+	// treat empty or whitespace-only string as NULL when scanning or
+	// unmarshaling
+	EmptyIsNull bool
+}
+
+func (n *NullInfo) parse(str string) (err error) {
+	if n.EmptyIsNull && strings.TrimSpace(str) == "" {
+		n.Info, n.Valid = NoCard, false
+		return
+	}
+
+	info, err := Parse(str)
+	if err != nil {
+		n.Info, n.Valid = NoCard, false
+		return
+	}
+	n.Info, n.Valid = info, true
+	return
+}
+
+// Scan implements sql.Scanner
+func (n *NullInfo) Scan(src interface{}) (err error) {
+	switch v := src.(type) {
+	case nil:
+		n.Info, n.Valid = NoCard, false
+		return
+	case string:
+		return n.parse(v)
+	case []byte:
+		return n.parse(string(v))
+	}
+	n.Info, n.Valid = NoCard, false
+	return ErrNullInfoType
+}
+
+// Value implements driver.Valuer, it returns raw PAN of unmasked Info, see
+// NullInfo
+func (n NullInfo) Value() (ret driver.Value, err error) {
+	if !n.Valid || n.Info == nil || n.Info.IsZero() {
+		return nil, nil
+	}
+	return n.Info.Canonical(), nil
+}
+
+// MarshalJSON implements json.Marshaler, it writes raw PAN of unmasked
+// Info, see NullInfo
+func (n NullInfo) MarshalJSON() (ret []byte, err error) {
+	if !n.Valid || n.Info == nil || n.Info.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.Info.Canonical())
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (n *NullInfo) UnmarshalJSON(b []byte) (err error) {
+	if string(b) == "null" {
+		n.Info, n.Valid = NoCard, false
+		return
+	}
+
+	var str string
+	if err = json.Unmarshal(b, &str); err != nil {
+		return
+	}
+	return n.parse(str)
+}
@@ -0,0 +1,102 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestNullInfoScan(t *testing.T) {
+	cases := []struct {
+		src         interface{}
+		emptyIsNull bool
+		valid       bool
+		err         error
+	}{
+		{nil, false, false, nil},
+		{"4111111111111111", false, true, nil},
+		{[]byte("411111******1111"), false, true, nil},
+		{"", true, false, nil},
+		{" ", true, false, nil},
+		{"", false, false, ErrRaw},
+		{"4111", true, false, ErrRaw},
+		{1, false, false, ErrNullInfoType},
+	}
+
+	for _, c := range cases {
+		n := NullInfo{Info: NoCard, Valid: true, EmptyIsNull: c.emptyIsNull}
+		err := n.Scan(c.src)
+		if !errors.Is(err, c.err) {
+			t.Log("expect:", c.err)
+			t.Log("actual:", err)
+			t.Fatalf("unexpected error scanning %v", c.src)
+		}
+		if n.Valid != c.valid || n.Info == nil || n.Info.IsZero() == c.valid {
+			t.Fatalf("unexpected result scanning %v: %+v", c.src, n)
+		}
+
+		// round trip
+		v, _ := n.Value()
+		m := NullInfo{}
+		if err = m.Scan(v); err != nil || m.Valid != n.Valid {
+			t.Fatalf("round trip failed for %v: %v", c.src, err)
+		}
+		if n.Valid && m.Info.Canonical() != n.Info.Canonical() {
+			t.Log("expect:", n.Info.Canonical())
+			t.Log("actual:", m.Info.Canonical())
+			t.Fatal("unexpected round trip result")
+		}
+	}
+}
+
+func TestNullInfoJSON(t *testing.T) {
+	type record struct {
+		Card NullInfo `json:"card"`
+	}
+	info, _ := FromRaw("411111******1111")
+	cases := map[string]record{
+		`{"card":null}`:               {},
+		`{"card":"411111******1111"}`: {Card: NullInfo{Info: info, Valid: true}},
+	}
+
+	for expect, r := range cases {
+		t.Run(expect, func(t *testing.T) {
+			actual, err := json.Marshal(r)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if string(actual) != expect {
+				t.Log("expect:", expect)
+				t.Log("actual:", string(actual))
+				t.Fatal("unexpected result")
+			}
+
+			var x record
+			if err = json.Unmarshal(actual, &x); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if x.Card.Valid != r.Card.Valid {
+				t.Fatal("unexpected round trip result")
+			}
+		})
+	}
+
+	x := record{Card: NullInfo{EmptyIsNull: true}}
+	if err := json.Unmarshal([]byte(`{"card":""}`), &x); err != nil || x.Card.Valid || x.Card.Info != NoCard {
+		t.Fatal("unexpected result:", err)
+	}
+	if err := json.Unmarshal([]byte(`{"card":null}`), &x); err != nil || x.Card.Valid || x.Card.Info != NoCard {
+		t.Fatal("unexpected result:", err)
+	}
+	if b, err := json.Marshal(record{Card: NullInfo{Info: NoCard, Valid: true}}); err != nil || string(b) != `{"card":null}` {
+		t.Fatal("unexpected result:", string(b), err)
+	}
+	x.Card.EmptyIsNull = false
+	if err := json.Unmarshal([]byte(`{"card":""}`), &x); !errors.Is(err, ErrRaw) {
+		t.Fatal("unexpected error:", err)
+	}
+}
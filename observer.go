@@ -0,0 +1,32 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import "time"
+
+// Observer receives events for instrumentation, like collecting metrics
+//
+// No PAN data is passed to it. Methods are called synchronously, possibly from
+// multiple goroutines at the same time, so they should be fast and safe for
+// concurrent use.
+type Observer interface {
+	// called after FromSlice, FromPart, FromDashed, FromRaw or FromMasked
+	// returns, err is the returned error
+	OnParse(err error, dur time.Duration)
+	// called after Info.Validate returns, err is the returned error
+	OnValidate(t CardType, err error, dur time.Duration)
+}
+
+// observerBox wraps Observer so nil can be stored in atomic.Value
+type observerBox struct {
+	o Observer
+}
+
+// SetObserver sets the Observer used by the default Config, nil to remove it
+//
+// There's no overhead other than a nil check if no Observer is set.
+func SetObserver(o Observer) {
+	defaultConfig.SetObserver(o)
+}
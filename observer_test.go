@@ -0,0 +1,114 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type observerEvent struct {
+	name string
+	typ  CardType
+	err  error
+}
+
+type recordingObserver struct {
+	lock   sync.Mutex
+	events []observerEvent
+}
+
+func (o *recordingObserver) OnParse(err error, dur time.Duration) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.events = append(o.events, observerEvent{name: "parse", err: err})
+}
+
+func (o *recordingObserver) OnValidate(t CardType, err error, dur time.Duration) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.events = append(o.events, observerEvent{name: "validate", typ: t, err: err})
+}
+
+func TestObserver(t *testing.T) {
+	rec := &recordingObserver{}
+	SetObserver(rec)
+	defer SetObserver(nil)
+
+	info, _ := FromRaw("4111111111000066")
+	info.Validate()
+	FromRaw("411")
+	info, _ = FromDashed("5555-5555-5555-4445")
+	info.Validate()
+	info, _ = FromMasked("411111", "1111")
+	info.Validate()
+	info.AuditString()
+	FromPart("4111", "1111", "1111", "1111")
+	FromSlice([]string{"41111"})
+	Parse("4111111111111111")
+
+	expect := []observerEvent{
+		{name: "parse"},
+		{name: "validate", typ: VISACard, err: nil},
+		{name: "parse", err: newErrUnsupportedLength(3, variableLengths)},
+		{name: "parse"},
+		{name: "validate", typ: MasterCard, err: ErrValidate},
+		{name: "parse"},
+		{name: "validate", typ: VISACard, err: ErrValidateMasked},
+		{name: "parse"},
+		{name: "parse", err: ErrSection},
+		{name: "parse"},
+	}
+	if !reflect.DeepEqual(rec.events, expect) {
+		t.Log("expect:", expect)
+		t.Log("actual:", rec.events)
+		t.Fatal("unexpected events")
+	}
+
+	SetObserver(nil)
+	FromRaw("4111111111111111")
+	if len(rec.events) != len(expect) {
+		t.Fatal("observer is called after removed")
+	}
+}
+
+func TestObserverNoAlloc(t *testing.T) {
+	SetObserver(nil)
+	expect := testing.AllocsPerRun(100, func() { defaultConfig.fromRaw("4111111111111111") })
+	actual := testing.AllocsPerRun(100, func() { FromRaw("4111111111111111") })
+	if actual != expect {
+		t.Log("expect:", expect)
+		t.Log("actual:", actual)
+		t.Fatal("unexpected allocations")
+	}
+
+	i := &info{pan: "4111111111111111", typ: VISACard}
+	expect = testing.AllocsPerRun(100, func() { i.validate() })
+	actual = testing.AllocsPerRun(100, func() { i.Validate() })
+	if actual != expect {
+		t.Log("expect:", expect)
+		t.Log("actual:", actual)
+		t.Fatal("unexpected allocations in Validate")
+	}
+}
+
+func BenchmarkFromRawNoObserver(b *testing.B) {
+	SetObserver(nil)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		FromRaw("4111111111111111")
+	}
+}
+
+func BenchmarkFromRawObserver(b *testing.B) {
+	SetObserver(&recordingObserver{})
+	defer SetObserver(nil)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		FromRaw("4111111111111111")
+	}
+}
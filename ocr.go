@@ -0,0 +1,63 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+// common OCR misreads of digits
+var ocrCorrections = [256]byte{
+	'O': '0',
+	'o': '0',
+	'l': '1',
+	'I': '1',
+	'S': '5',
+	'B': '8',
+	'Z': '2',
+}
+
+// WithOCRCorrections makes constructors fix common OCR misreads before
+// parsing: O and o to 0, l and I to 1, S to 5, B to 8 and Z to 2
+//
+// Number of fixed characters is reported by Info.Corrections. A corrected
+// PAN is a guess, callers should at least require Validate to pass before
+// trusting it.
+func WithOCRCorrections() (ret Option) {
+	return func(c *Config) {
+		c.ocrCorrections = true
+	}
+}
+
+// correctOCR fixes OCR misreads in s, returns number of fixed characters
+func correctOCR(s string) (ret string, n int) {
+	var buf []byte
+	for idx := 0; idx < len(s); idx++ {
+		d := ocrCorrections[s[idx]]
+		if d == 0 {
+			continue
+		}
+		if buf == nil {
+			buf = []byte(s)
+		}
+		buf[idx] = d
+		n++
+	}
+	if buf == nil {
+		return s, 0
+	}
+	return string(buf), n
+}
+
+func (i *info) Corrections() (ret int) {
+	if i.IsZero() {
+		return
+	}
+	return i.corrections
+}
+
+// withCorrections records n corrections to ret if it is created
+func withCorrections(ret Info, n int) Info {
+	if x, ok := ret.(*info); ok && n > 0 {
+		x.corrections += n
+	}
+	return ret
+}
@@ -0,0 +1,64 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOCRCorrections(t *testing.T) {
+	c := New(WithOCRCorrections())
+	cases := []struct {
+		name        string
+		pan         string
+		expect      string
+		corrections int
+		err         error
+	}{
+		{"strict", "4111111111000066", "4111111111000066", 0, nil},
+		{"dashed", "4111-l111-11O0-0066", "4111111111000066", 2, nil},
+		{"raw", "SS82S828O58OOOO2", "5582582805800002", 8, nil},
+		{"mixed", "55BZ-5828-o58o-OOOZ", "5582582805800002", 8, nil},
+		{"luhn", "SS8Z-S8Z8-OS8O-OOOO", "5582582805800000", 12, ErrValidate},
+	}
+
+	for _, x := range cases {
+		t.Run(x.name, func(t *testing.T) {
+			info, err := c.Parse(x.pan)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if actual := info.RawPAN(); actual != x.expect {
+				t.Log("expect:", x.expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+			if actual := info.Corrections(); actual != x.corrections {
+				t.Log("expect:", x.corrections)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected corrections")
+			}
+			if err = info.Validate(); err != x.err {
+				t.Log("expect:", x.err)
+				t.Log("actual:", err)
+				t.Fatal("unexpected validation result")
+			}
+		})
+	}
+
+	info, err := c.FromMasked("SS8ZS8", "OOOZ")
+	if err != nil || info.RawPAN() != "558258******0002" || info.Corrections() != 8 {
+		t.Fatal("unexpected result:", info, err)
+	}
+
+	// default path stays strict
+	if _, err = Parse("4111-l111-11O0-0066"); !errors.Is(err, ErrSection) {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, err = c.Parse("4111-x111-11O0-0066"); !errors.Is(err, ErrSection) {
+		t.Fatal("unexpected error:", err)
+	}
+}
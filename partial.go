@@ -0,0 +1,111 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+// Possible errors returned by ValidatePartial
+const (
+	ErrIncomplete   ErrPANFormat = "pan is incomplete"
+	ErrNotDigit     ErrPANFormat = "pan must be composed by digits"
+	ErrUnknownBrand ErrPANFormat = "pan does not belong to any supported brand"
+)
+
+// possibleBrands returns brands which a PAN starting with prefix might be
+//
+// prefix must be composed by digits.
+func possibleBrands(prefix string) (ret []CardType) {
+	seen := map[CardType]bool{}
+	add := func(t CardType) {
+		if t != UnknownCardType && !seen[t] {
+			seen[t] = true
+			ret = append(ret, t)
+		}
+	}
+
+	if len(prefix) >= 4 {
+		add(defaultConfig.cardType(prefix))
+	} else {
+		// most brands are detected by first 4 digits, try all completions
+		n := 1
+		for x := len(prefix); x < 4; x++ {
+			n *= 10
+		}
+		buf := []byte(prefix + "000")[:4]
+		for v := 0; v < n; v++ {
+			x := v
+			for idx := 3; idx >= len(prefix); idx-- {
+				buf[idx] = byte('0' + x%10)
+				x /= 10
+			}
+			add(defaultConfig.cardType(string(buf)))
+		}
+	}
+
+	// ranges longer than prefix might be matched once more digits are typed
+	v, _ := prefixValue(prefix, len(prefix))
+	for _, r := range prefixRanges {
+		if r.digits <= len(prefix) {
+			continue
+		}
+		scale := 1
+		for x := len(prefix); x < r.digits; x++ {
+			scale *= 10
+		}
+		if lo := v * scale; lo <= r.hi && lo+scale-1 >= r.lo {
+			add(r.typ)
+		}
+	}
+	return
+}
+
+// ValidatePartial validates a PAN which is being typed
+//
+// Spaces and dashes are ignored. It returns
+//
+//   - nil if prefix is a valid PAN of a supported brand, more digits might be
+//     accepted if the brand allows longer PANs
+//   - ErrIncomplete if prefix is fine so far, but more digits are needed
+//   - ErrNotDigit if prefix contains other characters
+//   - ErrUnknownBrand if prefix does not start like any supported brand
+//   - ErrBrandLength if prefix is longer than the brand allows
+//   - ErrValidate if prefix has the max length of the brand but fails luhn check
+func ValidatePartial(prefix string) (err error) {
+	buf := make([]byte, 0, len(prefix))
+	for x := 0; x < len(prefix); x++ {
+		switch c := prefix[x]; {
+		case isDigit(c):
+			buf = append(buf, c)
+		case !isPANSeparator(c):
+			return ErrNotDigit
+		}
+	}
+	pan := string(buf)
+	if pan == "" {
+		return ErrIncomplete
+	}
+
+	brands := possibleBrands(pan)
+	if len(brands) == 0 {
+		return ErrUnknownBrand
+	}
+
+	l, max, complete := len(pan), 0, false
+	for _, t := range brands {
+		for _, x := range t.ValidPANLengths() {
+			if x > max {
+				max = x
+			}
+			complete = complete || x == l
+		}
+	}
+	switch {
+	case l > max:
+		return ErrBrandLength
+	case complete && checkLuhn(pan):
+		return nil
+	case l == max:
+		return ErrValidate
+	}
+	return ErrIncomplete
+}
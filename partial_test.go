@@ -0,0 +1,89 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidatePartial(t *testing.T) {
+	// digit by digit through a valid card
+	pan := "4111111111000066"
+	for l := 0; l < len(pan); l++ {
+		if err := ValidatePartial(pan[:l]); err != ErrIncomplete {
+			t.Log("expect:", ErrIncomplete)
+			t.Log("actual:", err)
+			t.Fatal("unexpected result of", pan[:l])
+		}
+	}
+	if err := ValidatePartial(pan); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	// visa allows 19 digits
+	if err := ValidatePartial(pan + "1"); err != ErrIncomplete {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// goes wrong at digit 3: mir is 2200-2204, mastercard 2-series starts
+	// from 2221
+	bad := "2210123412341234"
+	for l := 1; l < 3; l++ {
+		if err := ValidatePartial(bad[:l]); err != ErrIncomplete {
+			t.Fatal("unexpected error of", bad[:l], err)
+		}
+	}
+	for l := 3; l <= len(bad); l++ {
+		if err := ValidatePartial(bad[:l]); err != ErrUnknownBrand {
+			t.Log("expect:", ErrUnknownBrand)
+			t.Log("actual:", err)
+			t.Fatal("unexpected result of", bad[:l])
+		}
+	}
+
+	cases := map[string]error{
+		"4111 1111 1100 0066": nil,
+		"5555-5555-5555-4445": ErrValidate,
+		"55555555555544441":   ErrBrandLength,
+		"378282246310005":     nil,
+		"378282246310006":     ErrValidate,
+		"3782822463100051":    ErrBrandLength,
+		"41a":                 ErrNotDigit,
+		"7":                   ErrUnknownBrand,
+	}
+	for input, expect := range cases {
+		if actual := ValidatePartial(input); actual != expect {
+			t.Log("expect:", expect)
+			t.Log("actual:", actual)
+			t.Fatal("unexpected result of", input)
+		}
+	}
+}
+
+func TestPossibleBrands(t *testing.T) {
+	cases := []struct {
+		prefix string
+		expect []CardType
+	}{
+		{"7", nil},
+		{"37", []CardType{AmericanExpress}},
+		{"35", []CardType{JCBCard}},
+		{"417", []CardType{VISACard, VisaElectron}},
+		{"4175", []CardType{VISACard, VisaElectron}},
+		{"41750", []CardType{VISACard, VisaElectron}},
+		{"417500", []CardType{VisaElectron}},
+		{"417501", []CardType{VISACard}},
+		{"6007", []CardType{RuPayCard, Forbrugsforeningen}},
+		{"600722", []CardType{Forbrugsforeningen}},
+		{"6062", []CardType{RuPayCard, HipercardCard}},
+	}
+	for _, c := range cases {
+		if actual := possibleBrands(c.prefix); !reflect.DeepEqual(actual, c.expect) {
+			t.Log("expect:", c.expect)
+			t.Log("actual:", actual)
+			t.Fatal("unexpected result of", c.prefix)
+		}
+	}
+}
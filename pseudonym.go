@@ -0,0 +1,33 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base32"
+	"strings"
+)
+
+// PseudonymPrefix is the prefix of pseudonyms returned by Info.Pseudonym
+const PseudonymPrefix = "card_"
+
+var pseudonymEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func (i *info) Pseudonym(key []byte) (ret string, err error) {
+	if i.IsZero() {
+		return "", ErrNoCard
+	}
+	pan := i.RawPAN()
+	if strings.Contains(pan, "*") {
+		err = ErrMaskedPAN
+		return
+	}
+
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(pan))
+	mac := h.Sum(nil)[:16]
+	return PseudonymPrefix + strings.ToLower(pseudonymEncoding.EncodeToString(mac)), nil
+}
@@ -0,0 +1,60 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPseudonym(t *testing.T) {
+	cases := map[string]string{
+		"4111111111111111": "card_27plf6ets2twfyhuqx7wfg7nqa",
+		"5555555555554444": "card_5by3elfgz5vycvtwmr3hynytsm",
+	}
+
+	for pan, expect := range cases {
+		t.Run(pan, func(t *testing.T) {
+			info, _ := FromRaw(pan)
+			actual, err := info.Pseudonym([]byte("key"))
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if actual != expect {
+				t.Log("expect:", expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+		})
+	}
+
+	masked, _ := FromMasked("411111", "1111")
+	if _, err := masked.Pseudonym([]byte("key")); err != ErrMaskedPAN {
+		t.Log("expect:", ErrMaskedPAN)
+		t.Log("actual:", err)
+		t.Fatal("unexpected error")
+	}
+}
+
+func TestPseudonymCollision(t *testing.T) {
+	n := 100000
+	if testing.Short() {
+		n = 1000
+	}
+	key := []byte("key")
+	seen := make(map[string]string, n)
+	for x := 0; x < n; x++ {
+		pan := fmt.Sprintf("4%015d", x*7919)
+		info, _ := FromRaw(pan)
+		p, err := info.Pseudonym(key)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if prev, ok := seen[p]; ok {
+			t.Fatalf("collision between %s and %s", prev, pan)
+		}
+		seen[p] = pan
+	}
+}
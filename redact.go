@@ -0,0 +1,230 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import "encoding/json"
+
+// maskPANText replaces digits of a PAN-like sequence with asterisks, except
+// first 6 and last 4 digits
+func maskPANText(b []byte) {
+	total := 0
+	for _, c := range b {
+		if isDigit(c) {
+			total++
+		}
+	}
+
+	n := 0
+	for idx, c := range b {
+		if !isDigit(c) {
+			continue
+		}
+		if n >= 6 && n < total-4 {
+			b[idx] = '*'
+		}
+		n++
+	}
+}
+
+// redact masks all PAN-like sequences in b, returns number of them
+func (m *panMatcher) redact(b []byte) (ret int) {
+	for len(b) > 0 {
+		start, end, _ := m.find(b, true)
+		if start < 0 {
+			return
+		}
+		maskPANText(b[start:end])
+		ret++
+		b = b[end:]
+	}
+	return
+}
+
+// scrubMatcher returns the matcher configured by opts
+func scrubMatcher(opts []ScrubOption) (ret *panMatcher) {
+	if len(opts) == 0 {
+		return defaultMatcher
+	}
+	return NewScrubber(opts...).m
+}
+
+// Redact masks PAN-like sequences in b, see ScanPANs for what is PAN-like
+//
+// Digits other than first 6 and last 4 are replaced by asterisks, separators
+// are kept as-is, so the length of data is not changed. b is not modified.
+//
+// What is PAN-like can be tuned by opts, like ScrubRequireLuhn or
+// ScrubAllowPattern. Options about keys are ignored.
+func Redact(b []byte, opts ...ScrubOption) (ret []byte) {
+	ret = make([]byte, len(b))
+	copy(ret, b)
+	scrubMatcher(opts).redact(ret)
+	return
+}
+
+// RedactString is same as Redact, but works on string
+func RedactString(s string, opts ...ScrubOption) (ret string) {
+	return string(Redact([]byte(s), opts...))
+}
+
+// ContainsPAN reports if there's any PAN-like sequence in b, see Redact for
+// opts
+func ContainsPAN(b []byte, opts ...ScrubOption) (ret bool) {
+	start, _, _ := scrubMatcher(opts).find(b, true)
+	return start >= 0
+}
+
+func isJSONNumberByte(c byte) (ret bool) {
+	return isDigit(c) || c == '.' || c == 'e' || c == 'E' || c == '+' || c == '-'
+}
+
+// jsonKeyRule defines how values of a key are processed in redactJSON
+type jsonKeyRule int
+
+const (
+	jsonDefault jsonKeyRule = iota
+	jsonSkip                // leave values alone
+	jsonForce               // mask 13-19 digits even if not luhn-valid
+)
+
+var forceMatcher = &panMatcher{noLuhn: true}
+
+// jsonScope is an object or array in redactJSON
+type jsonScope struct {
+	obj     bool
+	wantKey bool
+	key     string
+}
+
+// redactJSON masks PAN-like sequences in a JSON document
+//
+// PANs in strings (including keys) are masked in place. PANs written as bare
+// number are replaced by quoted masked string, so the result is still valid
+// JSON. rule decides how values (and everything nested in them) of a key are
+// processed, rule of the innermost key wins. m decides what is PAN-like
+// except for values of forced keys.
+//
+// Malformed JSON does not cause an error, it is processed as far as possible.
+func redactJSON(b []byte, rule func(key string) jsonKeyRule, m *panMatcher) (ret []byte, cnt int) {
+	ret = make([]byte, 0, len(b))
+	stack := make([]jsonScope, 0, 8)
+	current := func() (ret jsonKeyRule) {
+		if rule == nil {
+			return
+		}
+		for i := len(stack) - 1; i >= 0; i-- {
+			s := stack[i]
+			if !s.obj || s.wantKey {
+				continue
+			}
+			if r := rule(s.key); r != jsonDefault {
+				return r
+			}
+		}
+		return
+	}
+
+	for i := 0; i < len(b); {
+		c := b[i]
+		switch {
+		case c == '"':
+			end := i + 1
+			for end < len(b) && b[end] != '"' {
+				if b[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end > len(b) {
+				end = len(b)
+			}
+			x := len(ret) + 1
+			ret = append(ret, b[i:end]...)
+			isKey := len(stack) > 0 && stack[len(stack)-1].obj && stack[len(stack)-1].wantKey
+			if isKey {
+				stack[len(stack)-1].key = string(b[i+1 : end])
+			}
+			switch r := current(); {
+			case isKey, r == jsonDefault:
+				cnt += m.redact(ret[x:])
+			case r == jsonForce:
+				cnt += forceMatcher.redact(ret[x:])
+			}
+			if end < len(b) {
+				ret = append(ret, '"')
+				end++
+			}
+			i = end
+			continue
+		case isJSONNumberByte(c):
+			end := i
+			allDigits := true
+			for end < len(b) && isJSONNumberByte(b[end]) {
+				allDigits = allDigits && isDigit(b[end])
+				end++
+			}
+			tok := b[i:end]
+			n := len(tok)
+			r := jsonSkip
+			if allDigits && n >= minPANDigits && n <= maxPANDigits {
+				r = current()
+			}
+			if r == jsonForce || (r == jsonDefault && m.accept(tok)) {
+				ret = append(ret, '"')
+				x := len(ret)
+				ret = append(ret, tok...)
+				maskPANText(ret[x:])
+				ret = append(ret, '"')
+				cnt++
+			} else {
+				ret = append(ret, tok...)
+			}
+			i = end
+			continue
+		case c == '{':
+			stack = append(stack, jsonScope{obj: true, wantKey: true})
+		case c == '[':
+			stack = append(stack, jsonScope{})
+		case c == '}' || c == ']':
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case c == ':':
+			if len(stack) > 0 {
+				stack[len(stack)-1].wantKey = false
+			}
+		case c == ',':
+			if len(stack) > 0 && stack[len(stack)-1].obj {
+				stack[len(stack)-1].wantKey = true
+			}
+		}
+		ret = append(ret, c)
+		i++
+	}
+
+	return
+}
+
+// RedactJSON returns a copy of JSON document b with PAN-like sequences masked
+//
+// Strings and numbers are inspected, including those nested in objects and
+// arrays. PANs in strings are masked in place (see Redact), PANs written as
+// bare number are replaced by quoted masked string. Everything else, like key
+// order, white spaces and escape sequences, is kept as-is.
+//
+// Use ScrubSkipKeys to leave values of some keys alone, and ScrubForceKeys to
+// mask 13-19 digit sequences in values of some keys even if they are not
+// luhn-valid. Other options tune what is PAN-like, see Redact. An error is
+// returned if b is not valid JSON.
+func RedactJSON(b []byte, opts ...ScrubOption) (ret []byte, err error) {
+	var v json.RawMessage
+	if err = json.Unmarshal(b, &v); err != nil {
+		return
+	}
+
+	s := NewScrubber(opts...)
+	ret, _ = redactJSON(b, s.jsonRule, s.m)
+	return
+}
@@ -0,0 +1,98 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import "testing"
+
+func TestRedact(t *testing.T) {
+	cases := map[string]string{
+		"card 4111111111111111 ok":          "card 411111******1111 ok",
+		"4111 1111 1111 1111":               "4111 11** **** 1111",
+		"amex 3782-822463-10005":            "amex 3782-82****-*0005",
+		"4111111111111112":                  "4111111111111112",
+		"a 5555555555554444 b 1234 c":       "a 555555******4444 b 1234 c",
+		"6011000990139424,3530111333300000": "601100******9424,353011******0000",
+	}
+
+	for input, expect := range cases {
+		t.Run(input, func(t *testing.T) {
+			if actual := RedactString(input); actual != expect {
+				t.Log("expect:", expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+			if ContainsPAN([]byte(input)) != (input != expect) {
+				t.Fatal("unexpected ContainsPAN result")
+			}
+		})
+	}
+}
+
+func TestRedactJSON(t *testing.T) {
+	cases := map[string]string{
+		`{"pan":"4111111111111111"}`:          `{"pan":"411111******1111"}`,
+		`{"pan":4111111111111111}`:            `{"pan":"411111******1111"}`,
+		`[4111111111111111, 1]`:               `["411111******1111", 1]`,
+		`{"n":4111111111111111.5}`:            `{"n":4111111111111111.5}`,
+		`{"s":"a \"4111 1111 1111 1111\" b"}`: `{"s":"a \"4111 11** **** 1111\" b"}`,
+		`{"4111111111111111":true}`:           `{"411111******1111":true}`,
+		`{"skip":{"a":[4111111111111111,"4111111111111111"]},"b":4111111111111111}`: `{"skip":{"a":[4111111111111111,"4111111111111111"]},"b":"411111******1111"}`,
+		`{"a":[{"skip":1},"4111111111111111"]}`:                                     `{"a":[{"skip":1},"411111******1111"]}`,
+		`{"x":-4111111111111111}`:                                                   `{"x":-4111111111111111}`,
+		`{"x":"41111111111111`:                                                      `{"x":"41111111111111`,
+	}
+
+	for input, expect := range cases {
+		t.Run(input, func(t *testing.T) {
+			actual, _ := redactJSON([]byte(input), func(k string) jsonKeyRule {
+				if k == "skip" {
+					return jsonSkip
+				}
+				return jsonDefault
+			}, defaultMatcher)
+			if string(actual) != expect {
+				t.Log("expect:", expect)
+				t.Log("actual:", string(actual))
+				t.Fatal("unexpected result")
+			}
+		})
+	}
+}
+
+func TestRedactJSONOptions(t *testing.T) {
+	input := `{
+  "order_id": "4111111111111111",
+  "card": {"number": 4111111111111111, "history": ["5555 5555 5555 4444", 1234567890123456]},
+  "note": "café 4012888888881881",
+  "amount": 12.50,
+  "card_number": "4111111111111112",
+  "items": [{"sku": 1234567890123456}]
+}`
+	expect := `{
+  "order_id": "4111111111111111",
+  "card": {"number": "411111******1111", "history": ["5555 55** **** 4444", 1234567890123456]},
+  "note": "café 401288******1881",
+  "amount": 12.50,
+  "card_number": "411111******1112",
+  "items": [{"sku": 1234567890123456}]
+}`
+
+	actual, err := RedactJSON([]byte(input),
+		ScrubSkipKeys("order_id"),
+		ScrubForceKeys("card_number"),
+	)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if string(actual) != expect {
+		t.Log("expect:", expect)
+		t.Log("actual:", string(actual))
+		t.Fatal("unexpected result")
+	}
+
+	if _, err := RedactJSON([]byte(`{"pan":4111111111111111`)); err == nil {
+		t.Fatal("expected error for malformed json")
+	}
+}
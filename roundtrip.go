@@ -0,0 +1,235 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultLogBodySize is the default value of RoundTripBodyLimit
+const DefaultLogBodySize = 64 << 10
+
+// LogEntry is an outbound http call recorded by NewScrubRoundTripper
+//
+// PAN-like sequences in URL and bodies are masked, see Redact.
+type LogEntry struct {
+	Method     string
+	URL        string
+	StatusCode int           // 0 if the transport failed
+	Duration   time.Duration // until the response body is consumed or closed
+	Err        error         // error returned by the transport or response body
+
+	RequestBody       []byte
+	RequestTruncated  bool // RequestBody is truncated to body limit
+	ResponseBody      []byte
+	ResponseTruncated bool // ResponseBody is truncated to body limit
+}
+
+// RoundTripOption configures NewScrubRoundTripper
+type RoundTripOption func(t *scrubRoundTripper)
+
+// RoundTripBodyLimit sets max bytes of each body in LogEntry, default to
+// DefaultLogBodySize
+//
+// Bodies are truncated before masking. Digits at the end which might be part
+// of a truncated PAN are dropped too.
+func RoundTripBodyLimit(n int) (ret RoundTripOption) {
+	return func(t *scrubRoundTripper) {
+		t.limit = n
+	}
+}
+
+// RoundTripScrubOptions tunes what is PAN-like, see Redact
+func RoundTripScrubOptions(opts ...ScrubOption) (ret RoundTripOption) {
+	return func(t *scrubRoundTripper) {
+		t.m = scrubMatcher(opts)
+	}
+}
+
+type scrubRoundTripper struct {
+	base  http.RoundTripper
+	logFn func(entry LogEntry)
+	limit int
+	m     *panMatcher
+}
+
+// NewScrubRoundTripper wraps base to call logFn with a masked copy of every
+// request and response
+//
+// Data sent to and received from the network is not modified. Only first few
+// bytes (see RoundTripBodyLimit) of each body is buffered for logging. The
+// response body is not read in advance: bytes are recorded while the caller
+// reads them, and logFn is called once the body reaches EOF, fails or is
+// closed, so streaming responses work as usual. Like without this wrapper,
+// the response body must be closed. http.DefaultTransport is used if base is
+// nil.
+func NewScrubRoundTripper(base http.RoundTripper, logFn func(entry LogEntry), opts ...RoundTripOption) (ret http.RoundTripper) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	t := &scrubRoundTripper{
+		base:  base,
+		logFn: logFn,
+		limit: DefaultLogBodySize,
+		m:     defaultMatcher,
+	}
+	for _, o := range opts {
+		o(t)
+	}
+	return t
+}
+
+// readCloser reads from r and closes c
+type readCloser struct {
+	io.Reader
+	c io.Closer
+}
+
+func (r readCloser) Close() (err error) {
+	return r.c.Close()
+}
+
+// errReader always returns err
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read(p []byte) (n int, err error) {
+	return 0, r.err
+}
+
+// peek reads up to limit+1 bytes from body, and returns a body which reads
+// same bytes (and error) as the original
+func (t *scrubRoundTripper) peek(body io.ReadCloser) (head []byte, ret io.ReadCloser, err error) {
+	head, err = io.ReadAll(io.LimitReader(body, int64(t.limit)+1))
+	var rest io.Reader = body
+	if err != nil {
+		rest = errReader{err}
+	}
+	ret = readCloser{
+		Reader: io.MultiReader(bytes.NewReader(head), rest),
+		c:      body,
+	}
+	return
+}
+
+// scrub returns a masked copy of head, truncated to limit
+func (t *scrubRoundTripper) scrub(head []byte) (ret []byte, truncated bool) {
+	if len(head) <= t.limit {
+		ret = make([]byte, len(head))
+		copy(ret, head)
+		t.m.redact(ret)
+		return
+	}
+
+	b := make([]byte, t.limit)
+	copy(b, head)
+	ready := 0
+	for {
+		start, end, safe := t.m.find(b[ready:], false)
+		if start < 0 {
+			ready += safe
+			break
+		}
+		maskPANText(b[ready+start : ready+end])
+		ready += end
+	}
+	return b[:ready], true
+}
+
+func (t *scrubRoundTripper) RoundTrip(req *http.Request) (resp *http.Response, err error) {
+	u := []byte(req.URL.String())
+	t.m.redact(u)
+	entry := LogEntry{Method: req.Method, URL: string(u)}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		var head []byte
+		var body io.ReadCloser
+		head, body, err = t.peek(req.Body)
+		if err != nil {
+			req.Body.Close()
+			return
+		}
+		x := req.Clone(req.Context())
+		x.Body = body
+		req = x
+		entry.RequestBody, entry.RequestTruncated = t.scrub(head)
+	}
+
+	start := time.Now()
+	resp, err = t.base.RoundTrip(req)
+	if err != nil {
+		entry.Duration = time.Since(start)
+		entry.Err = err
+		t.logFn(entry)
+		return
+	}
+
+	entry.StatusCode = resp.StatusCode
+	if resp.Body == nil || resp.Body == http.NoBody {
+		entry.Duration = time.Since(start)
+		t.logFn(entry)
+		return
+	}
+	resp.Body = &logBody{ReadCloser: resp.Body, t: t, entry: entry, start: start}
+	return
+}
+
+// logBody records first bytes of response body read by the caller, and logs
+// the entry once it reaches EOF, fails or is closed
+type logBody struct {
+	io.ReadCloser
+	t     *scrubRoundTripper
+	entry LogEntry
+	start time.Time
+
+	lock sync.Mutex // Close might be called while reading
+	head []byte     // up to limit+1 bytes
+	done bool
+}
+
+func (b *logBody) Read(p []byte) (n int, err error) {
+	n, err = b.ReadCloser.Read(p)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if room := b.t.limit + 1 - len(b.head); room > 0 && !b.done {
+		if room > n {
+			room = n
+		}
+		b.head = append(b.head, p[:room]...)
+	}
+	if err != nil {
+		b.log(err)
+	}
+	return
+}
+
+func (b *logBody) Close() (err error) {
+	err = b.ReadCloser.Close()
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.log(nil)
+	return
+}
+
+// log calls logFn if not called yet, b.lock must be held
+func (b *logBody) log(err error) {
+	if b.done {
+		return
+	}
+	b.done = true
+	if err != nil && err != io.EOF {
+		b.entry.Err = err
+	}
+	b.entry.ResponseBody, b.entry.ResponseTruncated = b.t.scrub(b.head)
+	b.entry.Duration = time.Since(b.start)
+	b.t.logFn(b.entry)
+}
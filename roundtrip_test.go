@@ -0,0 +1,219 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestScrubRoundTripper(t *testing.T) {
+	var wire string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		wire = string(b)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"token":"tok_1","card":"5555555555554444"}`))
+	}))
+	defer srv.Close()
+
+	var entries []LogEntry
+	c := &http.Client{Transport: NewScrubRoundTripper(nil, func(e LogEntry) {
+		entries = append(entries, e)
+	})}
+
+	reqBody := `{"pan":"4111111111111111","amount":100}`
+	resp, err := c.Post(srv.URL+"/charge?pan=4111111111111111", "application/json", strings.NewReader(reqBody))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if wire != reqBody {
+		t.Log("expect:", reqBody)
+		t.Log("actual:", wire)
+		t.Fatal("request body is modified")
+	}
+	if expect := `{"token":"tok_1","card":"5555555555554444"}`; string(respBody) != expect {
+		t.Log("expect:", expect)
+		t.Log("actual:", string(respBody))
+		t.Fatal("response body is modified")
+	}
+
+	if len(entries) != 1 {
+		t.Fatal("unexpected number of entries:", len(entries))
+	}
+	e := entries[0]
+	expect := LogEntry{
+		Method:       "POST",
+		URL:          srv.URL + "/charge?pan=411111******1111",
+		StatusCode:   http.StatusCreated,
+		RequestBody:  []byte(`{"pan":"411111******1111","amount":100}`),
+		ResponseBody: []byte(`{"token":"tok_1","card":"555555******4444"}`),
+	}
+	e.Duration = 0
+	if e.Method != expect.Method || e.URL != expect.URL || e.StatusCode != expect.StatusCode ||
+		string(e.RequestBody) != string(expect.RequestBody) ||
+		string(e.ResponseBody) != string(expect.ResponseBody) ||
+		e.RequestTruncated || e.ResponseTruncated || e.Err != nil {
+		t.Log("expect:", expect)
+		t.Log("actual:", e)
+		t.Fatal("unexpected entry")
+	}
+}
+
+func TestScrubRoundTripperTruncate(t *testing.T) {
+	body := "card 4111111111111111 end"
+	var wire string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		wire = string(b)
+		w.Write(b)
+	}))
+	defer srv.Close()
+
+	cases := []struct {
+		limit  int
+		expect string
+		trunc  bool
+	}{
+		{limit: 20, expect: "card ", trunc: true},
+		{limit: 22, expect: "card ", trunc: true}, // PAN might go on after the space
+		{limit: 23, expect: "card 411111******1111 e", trunc: true},
+		{limit: 25, expect: "card 411111******1111 end"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.expect, func(t *testing.T) {
+			var entry LogEntry
+			client := &http.Client{Transport: NewScrubRoundTripper(nil, func(e LogEntry) {
+				entry = e
+			}, RoundTripBodyLimit(c.limit))}
+			resp, err := client.Post(srv.URL, "text/plain", strings.NewReader(body))
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			echo, _ := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+
+			if wire != body || string(echo) != body {
+				t.Fatal("data on the wire is modified")
+			}
+			if string(entry.RequestBody) != c.expect || entry.RequestTruncated != c.trunc {
+				t.Log("expect:", c.expect, c.trunc)
+				t.Log("actual:", string(entry.RequestBody), entry.RequestTruncated)
+				t.Fatal("unexpected request body")
+			}
+			if string(entry.ResponseBody) != c.expect || entry.ResponseTruncated != c.trunc {
+				t.Log("expect:", c.expect, c.trunc)
+				t.Log("actual:", string(entry.ResponseBody), entry.ResponseTruncated)
+				t.Fatal("unexpected response body")
+			}
+		})
+	}
+}
+
+type failTransport struct{}
+
+func (failTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.Body.Close()
+	return nil, errors.New("connection refused")
+}
+
+func TestScrubRoundTripperError(t *testing.T) {
+	var entry LogEntry
+	rt := NewScrubRoundTripper(failTransport{}, func(e LogEntry) {
+		entry = e
+	}, RoundTripScrubOptions(ScrubRequireLuhn(false)))
+	req := httptest.NewRequest("POST", "http://example.com/", strings.NewReader("id 4111111111111112"))
+	if _, err := rt.RoundTrip(req); err == nil {
+		t.Fatal("expected error")
+	}
+	if entry.Err == nil || entry.StatusCode != 0 || string(entry.RequestBody) != "id 411111******1112" {
+		t.Log("actual:", entry)
+		t.Fatal("unexpected entry")
+	}
+}
+
+func TestScrubRoundTripperStream(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("data: 4111111111111111\n"))
+		w.(http.Flusher).Flush()
+		<-release
+		w.Write([]byte("data: done\n"))
+	}))
+	defer srv.Close()
+
+	logged := make(chan LogEntry, 1)
+	c := &http.Client{Transport: NewScrubRoundTripper(nil, func(e LogEntry) {
+		logged <- e
+	})}
+	// RoundTrip returns before the response is complete
+	resp, err := c.Get(srv.URL)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	select {
+	case e := <-logged:
+		t.Fatal("logged before the body is consumed:", e)
+	default:
+	}
+
+	close(release)
+	body, _ := ioutil.ReadAll(resp.Body)
+	if expect := "data: 4111111111111111\ndata: done\n"; string(body) != expect {
+		t.Log("expect:", expect)
+		t.Log("actual:", string(body))
+		t.Fatal("response body is modified")
+	}
+	e := <-logged
+	if expect := "data: 411111******1111\ndata: done\n"; string(e.ResponseBody) != expect || e.Err != nil {
+		t.Log("expect:", expect)
+		t.Log("actual:", string(e.ResponseBody), e.Err)
+		t.Fatal("unexpected entry")
+	}
+
+	// closing again does not log twice
+	resp.Body.Close()
+	select {
+	case e := <-logged:
+		t.Fatal("logged twice:", e)
+	default:
+	}
+}
+
+func TestScrubRoundTripperClose(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("x", 1000)))
+	}))
+	defer srv.Close()
+
+	var entries []LogEntry
+	c := &http.Client{Transport: NewScrubRoundTripper(nil, func(e LogEntry) {
+		entries = append(entries, e)
+	}, RoundTripBodyLimit(100))}
+	resp, err := c.Get(srv.URL)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	buf := make([]byte, 10)
+	n, _ := resp.Body.Read(buf)
+	resp.Body.Close()
+	resp.Body.Close()
+
+	if len(entries) != 1 {
+		t.Fatal("unexpected number of entries:", len(entries))
+	}
+	if actual := string(entries[0].ResponseBody); actual != strings.Repeat("x", n) || entries[0].ResponseTruncated {
+		t.Log("actual:", actual, entries[0].ResponseTruncated)
+		t.Fatal("unexpected response body")
+	}
+}
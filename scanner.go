@@ -0,0 +1,210 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"bufio"
+	"io"
+)
+
+const (
+	minPANDigits = 13
+	maxPANDigits = 19
+	// minimal digits of each group in a separated PAN like "4111 1111 ..."
+	minPANGroup = 3
+)
+
+func isDigit(c byte) (ret bool) {
+	return c >= '0' && c <= '9'
+}
+
+func isPANSeparator(c byte) (ret bool) {
+	return c == ' ' || c == '-'
+}
+
+// Pattern matches digits of PAN-like sequences, *regexp.Regexp implements it
+type Pattern interface {
+	MatchString(s string) (ret bool)
+}
+
+// panMatcher decides what is PAN-like
+type panMatcher struct {
+	noLuhn     bool      // accept luhn-invalid sequences
+	minDigits  int       // minimal digits, 0 means minPANDigits
+	knownBrand bool      // accept only PANs of supported card types
+	allow      []Pattern // digits matching them are not PAN
+	deny       []string  // digits with these prefixes are always PAN
+}
+
+var defaultMatcher = &panMatcher{}
+
+func (m *panMatcher) accept(digits []byte) (ret bool) {
+	if len(digits) < m.minDigits {
+		return
+	}
+	for _, p := range m.deny {
+		if len(digits) >= len(p) && string(digits[:len(p)]) == p {
+			return true
+		}
+	}
+	if len(m.allow) > 0 {
+		str := string(digits)
+		for _, p := range m.allow {
+			if p.MatchString(str) {
+				return
+			}
+		}
+	}
+	if !m.noLuhn && !luhnValid(digits) {
+		return
+	}
+	if m.knownBrand && defaultConfig.cardType(string(digits)) == UnknownCardType {
+		return
+	}
+	return true
+}
+
+// chain computes the end of longest PAN-like sequence starting at b[start],
+// which must be the first digit of a digit group
+//
+// end is -1 if not found, more is true if it needs more data to decide.
+func (m *panMatcher) chain(b []byte, start int, atEOF bool) (end int, more bool) {
+	var buf [maxPANDigits]byte
+	digits := 0
+	end = -1
+	pos := start
+	for {
+		gEnd := pos
+		for gEnd < len(b) && isDigit(b[gEnd]) {
+			gEnd++
+		}
+		n := gEnd - pos
+		if gEnd == len(b) && !atEOF && digits+n <= maxPANDigits {
+			return end, true
+		}
+		if n < minPANGroup || digits+n > maxPANDigits {
+			return
+		}
+
+		copy(buf[digits:], b[pos:gEnd])
+		digits += n
+		if digits >= minPANDigits && m.accept(buf[:digits]) {
+			end = gEnd
+		}
+
+		if gEnd >= len(b) || !isPANSeparator(b[gEnd]) {
+			return
+		}
+		if gEnd+1 == len(b) {
+			return end, !atEOF
+		}
+		if !isDigit(b[gEnd+1]) {
+			return
+		}
+		pos = gEnd + 1
+	}
+}
+
+// find finds first PAN-like sequence in b
+//
+// If not found, start is -1 and b[:safe] contains no PAN even if more data is
+// appended to b.
+func (m *panMatcher) find(b []byte, atEOF bool) (start, end, safe int) {
+	i := 0
+	for i < len(b) {
+		if !isDigit(b[i]) {
+			i++
+			continue
+		}
+
+		gEnd := i
+		for gEnd < len(b) && isDigit(b[gEnd]) {
+			gEnd++
+		}
+		if gEnd == len(b) && !atEOF && gEnd-i > maxPANDigits {
+			// too long to be a PAN, keep enough digits so the rest of
+			// this group won't be treated as a new one
+			return -1, -1, len(b) - maxPANDigits - 1
+		}
+
+		e, more := m.chain(b, i, atEOF)
+		if e >= 0 && !more {
+			return i, e, e
+		}
+		if more {
+			return -1, -1, i
+		}
+		i = gEnd
+	}
+
+	return -1, -1, len(b)
+}
+
+func findPAN(b []byte, atEOF bool) (start, end, safe int) {
+	return defaultMatcher.find(b, atEOF)
+}
+
+// ScanPANs is a bufio.SplitFunc which returns each PAN-like sequence as token
+//
+// A PAN-like sequence is 13 to 19 digits which passes luhn check. Digits can
+// be separated into groups of at least 3 digits by single space or dash, like
+// "4111 1111 1111 1111" or "3782-822463-10005". Digit runs longer than 19
+// digits are ignored as a whole.
+//
+// If there're multiple candidates, the leftmost one wins, then the longest one.
+func ScanPANs(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	start, end, safe := findPAN(data, atEOF)
+	if start >= 0 {
+		return end, data[start:end], nil
+	}
+
+	return safe, nil, nil
+}
+
+// Scanner finds PAN-like sequences in a stream, see ScanPANs for detail
+type Scanner struct {
+	s      *bufio.Scanner
+	read   int64
+	offset int64
+}
+
+// NewScanner creates a Scanner which reads from r
+func NewScanner(r io.Reader) (ret *Scanner) {
+	ret = &Scanner{s: bufio.NewScanner(r)}
+	ret.s.Split(func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		advance, token, err = ScanPANs(data, atEOF)
+		if token != nil {
+			ret.offset = ret.read + int64(advance-len(token))
+		}
+		ret.read += int64(advance)
+		return
+	})
+	return
+}
+
+// Scan advances to next PAN-like sequence, see bufio.Scanner.Scan
+func (s *Scanner) Scan() (ret bool) {
+	return s.s.Scan()
+}
+
+// Bytes returns the PAN-like sequence found by last Scan, including separators
+func (s *Scanner) Bytes() (ret []byte) {
+	return s.s.Bytes()
+}
+
+// Text returns the PAN-like sequence found by last Scan, including separators
+func (s *Scanner) Text() (ret string) {
+	return s.s.Text()
+}
+
+// Offset returns the byte offset of the sequence found by last Scan
+func (s *Scanner) Offset() (ret int64) {
+	return s.offset
+}
+
+// Err returns first non-EOF error encountered, see bufio.Scanner.Err
+func (s *Scanner) Err() (err error) {
+	return s.s.Err()
+}
@@ -0,0 +1,166 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+type scanHit struct {
+	Offset int64
+	Text   string
+}
+
+func scanAll(t *testing.T, r io.Reader) (ret []scanHit) {
+	s := NewScanner(r)
+	for s.Scan() {
+		ret = append(ret, scanHit{Offset: s.Offset(), Text: s.Text()})
+	}
+	if err := s.Err(); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	return
+}
+
+// chunkReader returns at most n bytes in each Read
+type chunkReader struct {
+	r io.Reader
+	n int
+}
+
+func (r chunkReader) Read(buf []byte) (int, error) {
+	if len(buf) > r.n {
+		buf = buf[:r.n]
+	}
+	return r.r.Read(buf)
+}
+
+func TestScanPANs(t *testing.T) {
+	cases := []struct {
+		name   string
+		input  string
+		expect []scanHit
+	}{
+		{
+			name:   "plain",
+			input:  "card 4111111111111111 ok",
+			expect: []scanHit{{5, "4111111111111111"}},
+		},
+		{
+			name:   "spaced",
+			input:  "card: 4111 1111 1111 1111.",
+			expect: []scanHit{{6, "4111 1111 1111 1111"}},
+		},
+		{
+			name:   "dashed_amex",
+			input:  "amex=3782-822463-10005&x=1",
+			expect: []scanHit{{5, "3782-822463-10005"}},
+		},
+		{
+			name:  "multiple",
+			input: "5555555555554444,4012888888881881\n378282246310005",
+			expect: []scanHit{
+				{0, "5555555555554444"},
+				{17, "4012888888881881"},
+				{34, "378282246310005"},
+			},
+		},
+		{
+			name:   "trailing_number",
+			input:  "4111 1111 1111 1111 2024",
+			expect: []scanHit{{0, "4111 1111 1111 1111"}},
+		},
+		{
+			name:   "leading_number",
+			input:  "2024 4111 1111 1111 1111",
+			expect: []scanHit{{5, "4111 1111 1111 1111"}},
+		},
+		{name: "luhn_fail", input: "4111111111111112"},
+		{name: "too_short", input: "411111111116"},
+		{name: "inside_long_run", input: "99994111111111111111"},
+		{name: "double_space", input: "4111  1111 1111 1111"},
+		{name: "short_group", input: "41 11 11 11 11 11 11 11"},
+		{name: "empty"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			for _, n := range []int{1, 2, 3, 7, 4096} {
+				actual := scanAll(t, chunkReader{strings.NewReader(c.input), n})
+				if !reflect.DeepEqual(c.expect, actual) {
+					t.Log("chunk:", n)
+					t.Log("expect:", c.expect)
+					t.Log("actual:", actual)
+					t.Fatal("unexpected result")
+				}
+			}
+		})
+	}
+}
+
+func TestScanPANsBufferBoundary(t *testing.T) {
+	const pan = "4111 1111 1111 1111"
+	for _, pos := range []int{65536 - 10, 65536 - 1, 65536, 4096 - 5} {
+		buf := bytes.Repeat([]byte{'x'}, pos)
+		buf = append(buf, pan...)
+		buf = append(buf, bytes.Repeat([]byte{'y'}, 100)...)
+
+		for _, r := range []io.Reader{
+			bytes.NewReader(buf),
+			iotest.HalfReader(bytes.NewReader(buf)),
+			chunkReader{bytes.NewReader(buf), 65536},
+		} {
+			actual := scanAll(t, r)
+			expect := []scanHit{{int64(pos), pan}}
+			if !reflect.DeepEqual(expect, actual) {
+				t.Log("position:", pos)
+				t.Log("expect:", expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+		}
+	}
+}
+
+func TestScanPANsDenseNumbers(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	buf := &bytes.Buffer{}
+	digits := func(n int) []byte {
+		ret := make([]byte, n)
+		for i := range ret {
+			ret[i] = byte('0' + rnd.Intn(10))
+		}
+		return ret
+	}
+
+	for buf.Len() < 1<<20 {
+		switch rnd.Intn(4) {
+		case 0: // long digit runs
+			buf.Write(digits(20 + rnd.Intn(200)))
+		case 1: // luhn-invalid 16 digits
+			d := digits(16)
+			if luhnValid(d) {
+				d[15] = '0' + (d[15]-'0'+1)%10
+			}
+			buf.Write(d)
+		case 2: // short numbers
+			buf.Write(digits(1 + rnd.Intn(12)))
+		case 3: // a huge digit run crossing buffer boundary
+			buf.Write(digits(70000))
+		}
+		buf.WriteByte(";,\n|"[rnd.Intn(4)])
+	}
+
+	if actual := scanAll(t, bytes.NewReader(buf.Bytes())); len(actual) != 0 {
+		t.Log("actual:", actual)
+		t.Fatal("unexpected hits")
+	}
+}
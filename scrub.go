@@ -0,0 +1,158 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import "strings"
+
+// ScrubOption configures a Scrubber
+type ScrubOption func(s *Scrubber)
+
+// ScrubSkipKeys prevents values of these keys (field names in structured
+// logging, for example) from being scrubbed
+func ScrubSkipKeys(keys ...string) (ret ScrubOption) {
+	return func(s *Scrubber) {
+		for _, k := range keys {
+			s.skipKeys[k] = true
+		}
+	}
+}
+
+// ScrubForceKeys makes values of these keys always scrubbed, 13-19 digit
+// sequences are masked even if they are not luhn-valid
+//
+// Only structured data (like JSON) supports it.
+func ScrubForceKeys(keys ...string) (ret ScrubOption) {
+	return func(s *Scrubber) {
+		for _, k := range keys {
+			s.forceKeys[k] = true
+		}
+	}
+}
+
+// ScrubRequireLuhn controls if PAN-like sequences must pass luhn check, it is
+// true by default
+func ScrubRequireLuhn(b bool) (ret ScrubOption) {
+	return func(s *Scrubber) {
+		s.m.noLuhn = !b
+	}
+}
+
+// ScrubMinLength ignores PAN-like sequences with less than n digits, the
+// default is 13
+func ScrubMinLength(n int) (ret ScrubOption) {
+	return func(s *Scrubber) {
+		s.m.minDigits = n
+	}
+}
+
+// ScrubRequireKnownBrand controls if PAN-like sequences must belong to a
+// supported card type, it is false by default
+func ScrubRequireKnownBrand(b bool) (ret ScrubOption) {
+	return func(s *Scrubber) {
+		s.m.knownBrand = b
+	}
+}
+
+// ScrubAllowPattern leaves PAN-like sequences matching p alone, it is useful
+// to exempt known formats of internal ids or tracking numbers
+//
+// p is matched against digits only, separators are removed.
+func ScrubAllowPattern(p Pattern) (ret ScrubOption) {
+	return func(s *Scrubber) {
+		s.m.allow = append(s.m.allow, p)
+	}
+}
+
+type prefixPattern []string
+
+func (p prefixPattern) MatchString(str string) (ret bool) {
+	for _, x := range p {
+		if strings.HasPrefix(str, x) {
+			return true
+		}
+	}
+	return
+}
+
+// ScrubAllowPrefix is like ScrubAllowPattern, but exempts sequences starting
+// with one of prefixes
+func ScrubAllowPrefix(prefixes ...string) (ret ScrubOption) {
+	return ScrubAllowPattern(prefixPattern(prefixes))
+}
+
+// ScrubDenyBINs makes 13-19 digit sequences starting with one of bins always
+// masked, even if they fail luhn check or match ScrubAllowPattern
+func ScrubDenyBINs(bins ...string) (ret ScrubOption) {
+	return func(s *Scrubber) {
+		s.m.deny = append(s.m.deny, bins...)
+	}
+}
+
+// Scrubber masks PAN-like sequences in text, it is the shared core of logging
+// integrations
+//
+// See Redact for how PANs are masked. A Scrubber is safe for concurrent use
+// once created.
+type Scrubber struct {
+	skipKeys  map[string]bool
+	forceKeys map[string]bool
+	m         *panMatcher
+}
+
+// NewScrubber creates a Scrubber
+func NewScrubber(opts ...ScrubOption) (ret *Scrubber) {
+	ret = &Scrubber{
+		skipKeys:  map[string]bool{},
+		forceKeys: map[string]bool{},
+		m:         &panMatcher{},
+	}
+	for _, o := range opts {
+		o(ret)
+	}
+	return
+}
+
+// SkipKey reports if values of key should be left alone
+func (s *Scrubber) SkipKey(key string) (ret bool) {
+	return s.skipKeys[key]
+}
+
+// ScrubBytes masks PAN-like sequences in b in place, returns number of them
+func (s *Scrubber) ScrubBytes(b []byte) (ret int) {
+	return s.m.redact(b)
+}
+
+// Scrub returns str with PAN-like sequences masked
+//
+// str is returned as-is without allocation if nothing is found.
+func (s *Scrubber) Scrub(str string) (ret string) {
+	start, _, _ := s.m.find([]byte(str), true)
+	if start < 0 {
+		return str
+	}
+
+	b := []byte(str)
+	s.m.redact(b[start:])
+	return string(b)
+}
+
+// ScrubJSON returns a copy of JSON document b with PAN-like sequences masked
+//
+// See RedactJSON for detail. Unlike RedactJSON, malformed JSON does not cause
+// an error, it is processed as far as possible.
+func (s *Scrubber) ScrubJSON(b []byte) (ret []byte) {
+	ret, _ = redactJSON(b, s.jsonRule, s.m)
+	return
+}
+
+func (s *Scrubber) jsonRule(key string) (ret jsonKeyRule) {
+	switch {
+	case s.skipKeys[key]:
+		return jsonSkip
+	case s.forceKeys[key]:
+		return jsonForce
+	}
+	return jsonDefault
+}
@@ -0,0 +1,72 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import "io"
+
+// size of buffer used by scrubReader, a PAN-like sequence is far shorter
+const scrubReaderSize = 4096
+
+type scrubReader struct {
+	r     io.Reader
+	m     *panMatcher
+	buf   []byte
+	ready int // buf[:ready] is scrubbed
+	err   error
+}
+
+// NewScrubReader creates an io.Reader which masks PAN-like sequences in data
+// read from r, see Redact
+//
+// Sequences split across reads are handled. Masking does not change the length
+// of data, so it returns exactly as many bytes as r. Only a small fixed-size
+// buffer is used, no matter how long the data is.
+func NewScrubReader(r io.Reader, opts ...ScrubOption) (ret io.Reader) {
+	return &scrubReader{
+		r:   r,
+		m:   scrubMatcher(opts),
+		buf: make([]byte, 0, scrubReaderSize),
+	}
+}
+
+// scan masks PANs after buf[ready], and moves ready forward as far as data
+// cannot be part of a PAN
+func (s *scrubReader) scan(atEOF bool) {
+	b := s.buf[s.ready:]
+	for {
+		start, end, safe := s.m.find(b, atEOF)
+		if start < 0 {
+			s.ready += safe
+			return
+		}
+		maskPANText(b[start:end])
+		s.ready += end
+		b = b[end:]
+	}
+}
+
+func (s *scrubReader) Read(p []byte) (n int, err error) {
+	for s.ready == 0 {
+		if s.err != nil {
+			if len(s.buf) == 0 {
+				return 0, s.err
+			}
+			s.scan(true)
+			break
+		}
+
+		var x int
+		x, s.err = s.r.Read(s.buf[len(s.buf):cap(s.buf)])
+		s.buf = s.buf[:len(s.buf)+x]
+		if x > 0 {
+			s.scan(false)
+		}
+	}
+
+	n = copy(p, s.buf[:s.ready])
+	s.buf = s.buf[:copy(s.buf, s.buf[n:])]
+	s.ready -= n
+	return
+}
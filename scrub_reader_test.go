@@ -0,0 +1,115 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// splitReader returns data in chunks of given sizes, then the rest at once
+type splitReader struct {
+	data  []byte
+	sizes []int
+}
+
+func (r *splitReader) Read(p []byte) (n int, err error) {
+	if len(r.data) == 0 {
+		return 0, io.EOF
+	}
+	size := len(r.data)
+	if len(r.sizes) > 0 {
+		size, r.sizes = r.sizes[0], r.sizes[1:]
+	}
+	if size > len(p) {
+		size = len(p)
+	}
+	n = copy(p, r.data[:size])
+	r.data = r.data[n:]
+	return
+}
+
+func TestScrubReader(t *testing.T) {
+	// PAN is split into "4111 11", "11 1111 11" and "11 ok"
+	input := "card 4111 1111 1111 1111 ok"
+	r := NewScrubReader(&splitReader{data: []byte(input), sizes: []int{12, 10}})
+	actual, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	expect := "card 4111 11** **** 1111 ok"
+	if string(actual) != expect {
+		t.Log("expect:", expect)
+		t.Log("actual:", string(actual))
+		t.Fatal("unexpected result")
+	}
+}
+
+func TestScrubReaderOptions(t *testing.T) {
+	r := NewScrubReader(strings.NewReader("9000123456789016 4111111111111112"),
+		ScrubAllowPrefix("9000"), ScrubRequireLuhn(false))
+	actual, _ := ioutil.ReadAll(r)
+	expect := "9000123456789016 411111******1112"
+	if string(actual) != expect {
+		t.Log("expect:", expect)
+		t.Log("actual:", string(actual))
+		t.Fatal("unexpected result")
+	}
+}
+
+func TestScrubReaderRedact(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	pieces := []string{
+		"4111111111111111", "4111 1111 1111 1111", "3782-822463-10005",
+		"5555555555554444", "1234567890123456", "12345678901234567890123",
+		" ", "-", "a", "\n", "0", "42", "{\"pan\":", "\"",
+	}
+	for round := 0; round < 200; round++ {
+		buf := &bytes.Buffer{}
+		for buf.Len() < 10000 {
+			buf.WriteString(pieces[r.Intn(len(pieces))])
+		}
+		data := buf.Bytes()
+		expect := Redact(data)
+
+		readers := map[string]io.Reader{
+			"one_byte": iotest.OneByteReader(bytes.NewReader(data)),
+			"half":     iotest.HalfReader(bytes.NewReader(data)),
+			"data_err": iotest.DataErrReader(bytes.NewReader(data)),
+			"whole":    bytes.NewReader(data),
+			"chunk_7":  chunkReader{bytes.NewReader(data), 7},
+		}
+		for name, src := range readers {
+			actual, err := ioutil.ReadAll(NewScrubReader(src))
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if !bytes.Equal(actual, expect) {
+				t.Log("expect:", string(expect))
+				t.Log("actual:", string(actual))
+				t.Fatal("unexpected result of", name, "in round", round)
+			}
+		}
+	}
+}
+
+func TestScrubReaderError(t *testing.T) {
+	r := NewScrubReader(iotest.TimeoutReader(strings.NewReader("card 4111111111111111")))
+	actual, err := ioutil.ReadAll(r)
+	if err != iotest.ErrTimeout {
+		t.Fatal("unexpected error:", err)
+	}
+	// data before the error must be scrubbed and returned
+	if expect := "card 411111******1111"; string(actual) != expect {
+		t.Log("expect:", expect)
+		t.Log("actual:", string(actual))
+		t.Fatal("unexpected result")
+	}
+}
@@ -0,0 +1,85 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestScrubber(t *testing.T) {
+	s := NewScrubber(ScrubSkipKeys("order_id"))
+	cases := map[string]string{
+		"paid with 4111111111111111": "paid with 411111******1111",
+		"order 1234567890123456":     "order 1234567890123456",
+		"":                           "",
+	}
+	for input, expect := range cases {
+		if actual := s.Scrub(input); actual != expect {
+			t.Log("expect:", expect)
+			t.Log("actual:", actual)
+			t.Fatal("unexpected result")
+		}
+	}
+
+	if !s.SkipKey("order_id") || s.SkipKey("pan") {
+		t.Fatal("unexpected SkipKey result")
+	}
+}
+
+func TestScrubOptions(t *testing.T) {
+	cases := []struct {
+		name   string
+		opts   []ScrubOption
+		input  string
+		expect string
+	}{
+		{"luhn_default", nil, "id 4111111111111112", "id 4111111111111112"},
+		{"luhn_off", []ScrubOption{ScrubRequireLuhn(false)}, "id 4111111111111112", "id 411111******1112"},
+		{"luhn_on", []ScrubOption{ScrubRequireLuhn(true)}, "id 4111111111111112", "id 4111111111111112"},
+		{"min_default", nil, "amex 378282246310005", "amex 378282*****0005"},
+		{"min_16", []ScrubOption{ScrubMinLength(16)}, "amex 378282246310005", "amex 378282246310005"},
+		{"min_16_visa", []ScrubOption{ScrubMinLength(16)}, "4111111111111111", "411111******1111"},
+		{"brand_default", nil, "ref 6011000990139424", "ref 601100******9424"},
+		{"brand_known", []ScrubOption{ScrubRequireKnownBrand(true)}, "ref 6011000990139424", "ref 6011000990139424"},
+		{"brand_known_visa", []ScrubOption{ScrubRequireKnownBrand(true)}, "4111 1111 1111 1111", "4111 11** **** 1111"},
+		{"pattern_default", nil, "tracking 9000123456789016", "tracking 900012******9016"},
+		{"pattern", []ScrubOption{ScrubAllowPattern(regexp.MustCompile("^9000"))}, "tracking 9000-1234-5678-9016", "tracking 9000-1234-5678-9016"},
+		{"prefix", []ScrubOption{ScrubAllowPrefix("8", "9000")}, "tracking 9000123456789016", "tracking 9000123456789016"},
+		{"prefix_miss", []ScrubOption{ScrubAllowPrefix("9001")}, "tracking 9000123456789016", "tracking 900012******9016"},
+		{"deny", []ScrubOption{ScrubDenyBINs("411111")}, "id 4111111111111112", "id 411111******1112"},
+		{"deny_other", []ScrubOption{ScrubDenyBINs("555555")}, "id 4111111111111112", "id 4111111111111112"},
+		{"deny_over_allow", []ScrubOption{ScrubAllowPrefix("4"), ScrubDenyBINs("411111")}, "id 4111111111111111", "id 411111******1111"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := NewScrubber(c.opts...).Scrub(c.input); actual != c.expect {
+				t.Log("expect:", c.expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+			if actual := RedactString(c.input, c.opts...); actual != c.expect {
+				t.Log("expect:", c.expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result of RedactString")
+			}
+			if ContainsPAN([]byte(c.input), c.opts...) != (c.input != c.expect) {
+				t.Fatal("unexpected ContainsPAN result")
+			}
+		})
+	}
+}
+
+func TestScrubOptionsJSON(t *testing.T) {
+	s := NewScrubber(ScrubAllowPrefix("9000"), ScrubDenyBINs("411111"))
+	input := `{"a":9000123456789016,"b":4111111111111112,"c":"4111111111111112","d":5555555555554444}`
+	expect := `{"a":9000123456789016,"b":"411111******1112","c":"411111******1112","d":"555555******4444"}`
+	if actual := string(s.ScrubJSON([]byte(input))); actual != expect {
+		t.Log("expect:", expect)
+		t.Log("actual:", actual)
+		t.Fatal("unexpected result")
+	}
+}
@@ -0,0 +1,59 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ErrSectionValue is returned by WithSection if index or value is invalid
+//
+// errors.Is(err, ErrSection) is true for it.
+type ErrSectionValue struct {
+	Index int // index of the section
+	Width int // expected width of the section, 0 if index is out of range
+}
+
+func (e ErrSectionValue) Error() (ret string) {
+	if e.Width == 0 {
+		return ErrPANFormat("section index " + strconv.Itoa(e.Index) +
+			" is out of range").Error()
+	}
+	return ErrPANFormat("section " + strconv.Itoa(e.Index) + " must be " +
+		strconv.Itoa(e.Width) + " digits or asterisks").Error()
+}
+
+// Is reports if target is ErrSection
+func (e ErrSectionValue) Is(target error) (ret bool) {
+	return target == ErrSection
+}
+
+func (i *info) WithSection(index int, value string) (ret Info, err error) {
+	if i.IsZero() {
+		return nil, ErrNoCard
+	}
+	arr := i.groups(i.pan)
+	if index < 0 || index >= len(arr) {
+		return nil, ErrSectionValue{Index: index}
+	}
+	w := len(arr[index])
+	if len(value) != w || !isPANChars(value) {
+		return nil, ErrSectionValue{Index: index, Width: w}
+	}
+
+	start := 0
+	for _, v := range arr[:index] {
+		start += len(v)
+	}
+
+	x := *i
+	arr[index] = value
+	x.pan = strings.Join(arr, "")
+	if start < maxPrefixDigits {
+		x.typ = x.config().cardType(x.pan)
+	}
+	return &x, nil
+}
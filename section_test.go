@@ -0,0 +1,125 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithSection(t *testing.T) {
+	info, _ := FromRaw("4111222233334444")
+	cases := []struct {
+		index  int
+		value  string
+		expect string
+		typ    CardType
+	}{
+		{0, "5555", "5555222233334444", MasterCard},
+		{0, "3528", "3528222233334444", JCBCard},
+		{0, "4000", "4000222233334444", VISACard},
+		{0, "****", "****222233334444", UnknownCardType},
+		{1, "9999", "4111999933334444", VISACard},
+		{2, "9012", "4111222290124444", VISACard},
+		{3, "**44", "411122223333**44", VISACard},
+	}
+
+	for _, c := range cases {
+		t.Run(c.expect, func(t *testing.T) {
+			x, err := info.WithSection(c.index, c.value)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if actual := x.RawPAN(); actual != c.expect {
+				t.Log("expect:", c.expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+			if actual := x.CardType(); actual != c.typ {
+				t.Log("expect:", c.typ)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected card type")
+			}
+		})
+	}
+
+	if info.RawPAN() != "4111222233334444" || info.CardType() != VISACard {
+		t.Fatal("original info is modified")
+	}
+}
+
+func TestWithSectionBIN(t *testing.T) {
+	// elo is detected by 6 digits
+	elo, _ := FromRaw("4011780000000006")
+	cases := []struct {
+		info   Info
+		index  int
+		value  string
+		expect CardType
+	}{
+		{elo, 1, "1111", VISACard},
+		{elo, 1, "7800", EloCard},
+		{elo, 1, "****", VISACard},
+		{elo, 2, "1111", EloCard},
+		{mustMasked(t, "4011**", "0006"), 1, "7800", EloCard},
+		{mustMasked(t, "4011**", "0006"), 1, "7500", VISACard},
+		{mustMasked(t, "4175**", "0001"), 1, "00**", VisaElectron},
+		{mustMasked(t, "6007**", "0000"), 1, "22**", Forbrugsforeningen},
+	}
+	for _, c := range cases {
+		x, err := c.info.WithSection(c.index, c.value)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if actual := x.CardType(); actual != c.expect {
+			t.Log("expect:", c.expect)
+			t.Log("actual:", actual)
+			t.Fatal("unexpected card type of", x.RawPAN())
+		}
+		y, _ := FromRaw(x.RawPAN())
+		if y.CardType() != x.CardType() {
+			t.Fatal("card type differs from FromRaw:", x.RawPAN(), y.CardType())
+		}
+	}
+}
+
+func mustMasked(t *testing.T, first6, last4 string) (ret Info) {
+	ret, err := FromMasked(first6, last4)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	return
+}
+
+func TestWithSectionError(t *testing.T) {
+	info, _ := FromRaw("4111222233334444")
+	cases := []struct {
+		name  string
+		index int
+		value string
+		err   ErrSectionValue
+	}{
+		{"negative", -1, "1234", ErrSectionValue{Index: -1}},
+		{"too_large", 4, "1234", ErrSectionValue{Index: 4}},
+		{"short", 2, "123", ErrSectionValue{Index: 2, Width: 4}},
+		{"long", 1, "12345", ErrSectionValue{Index: 1, Width: 4}},
+		{"letter", 3, "12a4", ErrSectionValue{Index: 3, Width: 4}},
+		{"dash", 0, "41-1", ErrSectionValue{Index: 0, Width: 4}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := info.WithSection(c.index, c.value)
+			if err != c.err {
+				t.Log("expect:", c.err)
+				t.Log("actual:", err)
+				t.Fatal("unexpected error")
+			}
+			if !errors.Is(err, ErrSection) {
+				t.Fatal("error should be ErrSection")
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+// SetDefaultSeparator changes the group separator used by Info.PAN,
+// Info.Masked, Info.FullFirst6 and Info.FullLast4, defaults to "-"
+//
+// It applies to the default Config, see Config for using different separators
+// in same process. It is safe to call it concurrently with other functions.
+// FromDashed always uses "-" regardless of it.
+func SetDefaultSeparator(s string) {
+	defaultConfig.SetSeparator(s)
+}
@@ -0,0 +1,68 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestDefaultSeparator(t *testing.T) {
+	defer SetDefaultSeparator("-")
+	info, _ := FromRaw("4111111111111111")
+
+	cases := []struct {
+		sep    string
+		expect [4]string // PAN, Masked, FullFirst6, FullLast4
+	}{
+		{"-", [4]string{"4111-1111-1111-1111", "4111-11**-****-1111", "4111-11**-****-****", "****-****-****-1111"}},
+		{" ", [4]string{"4111 1111 1111 1111", "4111 11** **** 1111", "4111 11** **** ****", "**** **** **** 1111"}},
+		{"", [4]string{"4111111111111111", "411111******1111", "411111**********", "************1111"}},
+	}
+
+	for _, c := range cases {
+		t.Run("'"+c.sep+"'", func(t *testing.T) {
+			SetDefaultSeparator(c.sep)
+			actual := [4]string{info.PAN(), info.Masked(), info.FullFirst6(), info.FullLast4()}
+			if actual != c.expect {
+				t.Log("expect:", c.expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+
+			parsed, err := Parse(info.PAN())
+			if err != nil || parsed.RawPAN() != info.RawPAN() {
+				t.Fatal("cannot parse PAN():", err)
+			}
+			if _, err = FromDashed("4111 1111 1111 1111"); err == nil {
+				t.Fatal("FromDashed should accept only dashes")
+			}
+		})
+	}
+}
+
+func TestDefaultSeparatorConcurrent(t *testing.T) {
+	defer SetDefaultSeparator("-")
+	info, _ := FromRaw("4111111111111111")
+	valid := map[string]bool{"4111-1111-1111-1111": true, "4111 1111 1111 1111": true}
+
+	wg := &sync.WaitGroup{}
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for x := 0; x < 1000; x++ {
+				if pan := info.PAN(); !valid[pan] {
+					t.Error("unexpected result:", pan)
+					return
+				}
+			}
+		}()
+	}
+	for x := 0; x < 1000; x++ {
+		SetDefaultSeparator([]string{" ", "-"}[x%2])
+	}
+	wg.Wait()
+}
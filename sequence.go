@@ -0,0 +1,28 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+// ErrSequence is returned by WithSequence if the number is out of range
+const ErrSequence ErrArgument = "pan sequence number must be 0-99"
+
+func (i *info) WithSequence(n int) (ret Info, err error) {
+	if i.IsZero() {
+		return nil, ErrNoCard
+	}
+	if n < 0 || n > 99 {
+		return nil, ErrSequence
+	}
+
+	x := *i
+	x.seq, x.hasSeq = n, true
+	return &x, nil
+}
+
+func (i *info) Sequence() (ret int, ok bool) {
+	if i.IsZero() {
+		return
+	}
+	return i.seq, i.hasSeq
+}
@@ -0,0 +1,145 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import "strings"
+
+// ErrTrack indicates there's something wrong with magnetic stripe track data
+type ErrTrack string
+
+func (e ErrTrack) Error() (ret string) {
+	return "creditcard: incorrect track data: " + string(e)
+}
+
+// Possible errors returned by track data helpers
+const (
+	ErrServiceCode ErrTrack = "service code must be 3 digits"
+)
+
+// ServiceCode is the 3-digit service code in track 1/2 (ISO 7813)
+//
+// Each element is the numeric value (not the ascii character) of a digit.
+type ServiceCode [3]byte
+
+// ParseServiceCode parses 3-digit service code like "201"
+func ParseServiceCode(s string) (ret ServiceCode, err error) {
+	if len(s) != 3 {
+		err = ErrServiceCode
+		return
+	}
+
+	for idx := range ret {
+		c := s[idx]
+		if c < '0' || c > '9' {
+			err = ErrServiceCode
+			return
+		}
+		ret[idx] = c - '0'
+	}
+	return
+}
+
+// Code returns the service code in digits, like "201"
+func (c ServiceCode) Code() (ret string) {
+	return string([]byte{c[0] + '0', c[1] + '0', c[2] + '0'})
+}
+
+// InternationalUse reports if the card can be used for international
+// interchange (first digit 1 or 2)
+func (c ServiceCode) InternationalUse() (ret bool) {
+	return c[0] == 1 || c[0] == 2
+}
+
+// NationalUse reports if the card is limited to national interchange except
+// under bilateral agreement (first digit 5 or 6)
+func (c ServiceCode) NationalUse() (ret bool) {
+	return c[0] == 5 || c[0] == 6
+}
+
+// ChipRequired reports if integrated circuit should be used where feasible
+// (first digit 2 or 6)
+func (c ServiceCode) ChipRequired() (ret bool) {
+	return c[0] == 2 || c[0] == 6
+}
+
+// Test reports if this is a test card (first digit 9)
+func (c ServiceCode) Test() (ret bool) {
+	return c[0] == 9
+}
+
+// OnlineAuthorization reports if transactions must be authorized online by
+// the issuer (second digit 2 or 4)
+func (c ServiceCode) OnlineAuthorization() (ret bool) {
+	return c[1] == 2 || c[1] == 4
+}
+
+// PINRequired reports if PIN is required (third digit 0, 3 or 5)
+func (c ServiceCode) PINRequired() (ret bool) {
+	return c[2] == 0 || c[2] == 3 || c[2] == 5
+}
+
+// PINPreferred reports if PIN should be used where feasible (third digit 6
+// or 7)
+func (c ServiceCode) PINPreferred() (ret bool) {
+	return c[2] == 6 || c[2] == 7
+}
+
+// ATMOnly reports if the card can be used only at ATM (third digit 3)
+func (c ServiceCode) ATMOnly() (ret bool) {
+	return c[2] == 3
+}
+
+// CashOnly reports if the card can be used for cash only (third digit 4)
+func (c ServiceCode) CashOnly() (ret bool) {
+	return c[2] == 4
+}
+
+// GoodsAndServicesOnly reports if the card cannot be used for cash (third
+// digit 2, 5 or 7)
+func (c ServiceCode) GoodsAndServicesOnly() (ret bool) {
+	return c[2] == 2 || c[2] == 5 || c[2] == 7
+}
+
+var serviceCodeDesc = [3][10]string{
+	{
+		1: "international interchange",
+		2: "international interchange, use chip where feasible",
+		5: "national interchange only",
+		6: "national interchange only, use chip where feasible",
+		7: "no interchange except under bilateral agreement",
+		9: "test card",
+	},
+	{
+		0: "normal authorization",
+		2: "online authorization",
+		4: "online authorization except under bilateral agreement",
+	},
+	{
+		0: "no restrictions, PIN required",
+		1: "no restrictions",
+		2: "goods and services only",
+		3: "ATM only, PIN required",
+		4: "cash only",
+		5: "goods and services only, PIN required",
+		6: "no restrictions, use PIN where feasible",
+		7: "goods and services only, use PIN where feasible",
+	},
+}
+
+// String returns the code and human readable description of each digit,
+// like "201: international interchange, use chip where feasible; normal
+// authorization; no restrictions"
+func (c ServiceCode) String() (ret string) {
+	desc := make([]string, 3)
+	for idx, d := range c {
+		if d > 9 || serviceCodeDesc[idx][d] == "" {
+			desc[idx] = "reserved"
+			continue
+		}
+		desc[idx] = serviceCodeDesc[idx][d]
+	}
+
+	return c.Code() + ": " + strings.Join(desc, "; ")
+}
@@ -0,0 +1,120 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestServiceCode(t *testing.T) {
+	type flags struct {
+		International bool
+		National      bool
+		Chip          bool
+		Online        bool
+		PIN           bool
+		PINPreferred  bool
+		ATMOnly       bool
+		CashOnly      bool
+		NoCash        bool
+	}
+	cases := []struct {
+		code   string
+		expect flags
+		desc   string
+	}{
+		{
+			code:   "101",
+			expect: flags{International: true},
+			desc:   "101: international interchange; normal authorization; no restrictions",
+		},
+		{
+			code:   "201",
+			expect: flags{International: true, Chip: true},
+			desc:   "201: international interchange, use chip where feasible; normal authorization; no restrictions",
+		},
+		{
+			code:   "121",
+			expect: flags{International: true, Online: true},
+			desc:   "121: international interchange; online authorization; no restrictions",
+		},
+		{
+			code:   "206",
+			expect: flags{International: true, Chip: true, PINPreferred: true},
+			desc:   "206: international interchange, use chip where feasible; normal authorization; no restrictions, use PIN where feasible",
+		},
+		{
+			code:   "601",
+			expect: flags{National: true, Chip: true},
+			desc:   "601: national interchange only, use chip where feasible; normal authorization; no restrictions",
+		},
+		{
+			code:   "503",
+			expect: flags{National: true, PIN: true, ATMOnly: true},
+			desc:   "503: national interchange only; normal authorization; ATM only, PIN required",
+		},
+		{
+			code:   "125",
+			expect: flags{International: true, Online: true, PIN: true, NoCash: true},
+			desc:   "125: international interchange; online authorization; goods and services only, PIN required",
+		},
+		{
+			code:   "314",
+			expect: flags{CashOnly: true},
+			desc:   "314: reserved; reserved; cash only",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.code, func(t *testing.T) {
+			code, err := ParseServiceCode(c.code)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			actual := flags{
+				International: code.InternationalUse(),
+				National:      code.NationalUse(),
+				Chip:          code.ChipRequired(),
+				Online:        code.OnlineAuthorization(),
+				PIN:           code.PINRequired(),
+				PINPreferred:  code.PINPreferred(),
+				ATMOnly:       code.ATMOnly(),
+				CashOnly:      code.CashOnly(),
+				NoCash:        code.GoodsAndServicesOnly(),
+			}
+			if !reflect.DeepEqual(c.expect, actual) {
+				t.Logf("expect: %+v", c.expect)
+				t.Logf("actual: %+v", actual)
+				t.Fatal("unexpected result")
+			}
+
+			if code.Code() != c.code {
+				t.Log("expect:", c.code)
+				t.Log("actual:", code.Code())
+				t.Fatal("unexpected code")
+			}
+			if code.String() != c.desc {
+				t.Log("expect:", c.desc)
+				t.Log("actual:", code.String())
+				t.Fatal("unexpected description")
+			}
+		})
+	}
+}
+
+func TestParseServiceCodeError(t *testing.T) {
+	cases := []string{"", "20", "2011", "2a1", " 01", "-01", "２０１"}
+	for _, c := range cases {
+		t.Run(c, func(t *testing.T) {
+			if _, err := ParseServiceCode(c); err != ErrServiceCode {
+				t.Log("expect:", ErrServiceCode)
+				t.Log("actual:", err)
+				t.Fatal("unexpected result")
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"strings"
+)
+
+// ErrShardCount is returned by ShardKey if number of shards is less than 1
+const ErrShardCount ErrArgument = "number of shards must be positive"
+
+func (i *info) ShardKey(n int, key []byte) (ret int, err error) {
+	if i.IsZero() {
+		return 0, ErrNoCard
+	}
+	if n < 1 {
+		err = ErrShardCount
+		return
+	}
+	pan := i.RawPAN()
+	if strings.Contains(pan, "*") {
+		err = ErrMaskedPAN
+		return
+	}
+
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(pan))
+	v := binary.BigEndian.Uint64(h.Sum(nil))
+	return int(v % uint64(n)), nil
+}
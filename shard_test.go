@@ -0,0 +1,71 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestShardKey(t *testing.T) {
+	cases := []struct {
+		pan    string
+		key    string
+		n      int
+		expect int
+		err    error
+	}{
+		{pan: "4111111111111111", key: "key", n: 16, expect: 2},
+		{pan: "4111111111111111", key: "key", n: 1000, expect: 410},
+		{pan: "5555555555554444", key: "key", n: 16, expect: 1},
+		{pan: "5555555555554444", key: "other", n: 7, expect: 2},
+		{pan: "5555555555554444", key: "key", n: 1, expect: 0},
+		{pan: "5555555555554444", key: "key", n: 0, err: ErrShardCount},
+		{pan: "555555******4444", key: "key", n: 16, err: ErrMaskedPAN},
+	}
+
+	for _, c := range cases {
+		name := fmt.Sprintf("%s_%s_%d", c.pan, c.key, c.n)
+		t.Run(name, func(t *testing.T) {
+			info, _ := FromRaw(c.pan)
+			actual, err := info.ShardKey(c.n, []byte(c.key))
+			if err != c.err {
+				t.Log("expect (err):", c.err)
+				t.Log("actual (err):", err)
+				t.Fatal("unexpected error")
+			}
+			if actual != c.expect {
+				t.Log("expect:", c.expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+		})
+	}
+}
+
+func TestShardKeyDistribution(t *testing.T) {
+	const (
+		n     = 16
+		total = 16000
+	)
+	cnt := make([]int, n)
+	key := []byte("distribution")
+	for i := 0; i < total; i++ {
+		info, _ := FromRaw(fmt.Sprintf("4%015d", i*7919))
+		x, err := info.ShardKey(n, key)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		cnt[x]++
+	}
+
+	// each shard should get 1000 +- 20%
+	for idx, c := range cnt {
+		if c < 800 || c > 1200 {
+			t.Log("distribution:", cnt)
+			t.Fatalf("shard %d is unbalanced", idx)
+		}
+	}
+}
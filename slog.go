@@ -0,0 +1,109 @@
+//go:build go1.21
+
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"context"
+	"log/slog"
+)
+
+type scrubHandler struct {
+	next slog.Handler
+	s    *Scrubber
+}
+
+// NewScrubHandler creates a slog.Handler which masks PAN-like sequences in
+// message and string attributes of every record, and passes the record to
+// next, see Redact
+//
+// Attributes are walked recursively, including groups and values of
+// slog.LogValuer. Values of other kinds are left alone, as are values of keys
+// given by ScrubSkipKeys. Records without PAN are passed as-is.
+func NewScrubHandler(next slog.Handler, opts ...ScrubOption) (ret slog.Handler) {
+	return &scrubHandler{next: next, s: NewScrubber(opts...)}
+}
+
+func (h *scrubHandler) Enabled(ctx context.Context, l slog.Level) (ret bool) {
+	return h.next.Enabled(ctx, l)
+}
+
+func (h *scrubHandler) Handle(ctx context.Context, r slog.Record) (err error) {
+	msg := h.s.Scrub(r.Message)
+	changed := msg != r.Message
+	if !changed {
+		r.Attrs(func(a slog.Attr) bool {
+			_, changed = h.attr(a)
+			return !changed
+		})
+	}
+	if !changed {
+		return h.next.Handle(ctx, r)
+	}
+
+	x := slog.NewRecord(r.Time, r.Level, msg, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		a, _ = h.attr(a)
+		x.AddAttrs(a)
+		return true
+	})
+	return h.next.Handle(ctx, x)
+}
+
+func (h *scrubHandler) WithAttrs(attrs []slog.Attr) (ret slog.Handler) {
+	attrs, _ = h.attrs(attrs)
+	return &scrubHandler{next: h.next.WithAttrs(attrs), s: h.s}
+}
+
+func (h *scrubHandler) WithGroup(name string) (ret slog.Handler) {
+	return &scrubHandler{next: h.next.WithGroup(name), s: h.s}
+}
+
+// attrs scrubs attrs, attrs is returned as-is if nothing is changed
+func (h *scrubHandler) attrs(attrs []slog.Attr) (ret []slog.Attr, changed bool) {
+	for idx, a := range attrs {
+		x, ok := h.attr(a)
+		if ok && !changed {
+			changed = true
+			ret = append(make([]slog.Attr, 0, len(attrs)), attrs[:idx]...)
+		}
+		if changed {
+			ret = append(ret, x)
+		}
+	}
+	if !changed {
+		return attrs, false
+	}
+	return
+}
+
+func (h *scrubHandler) attr(a slog.Attr) (ret slog.Attr, changed bool) {
+	if h.s.SkipKey(a.Key) {
+		return a, false
+	}
+
+	v := a.Value
+	if v.Kind() == slog.KindLogValuer {
+		v = v.Resolve()
+	}
+	switch v.Kind() {
+	case slog.KindString:
+		str := v.String()
+		if x := h.s.Scrub(str); x != str {
+			return slog.String(a.Key, x), true
+		}
+	case slog.KindGroup:
+		if x, ok := h.attrs(v.Group()); ok {
+			return slog.Attr{Key: a.Key, Value: slog.GroupValue(x...)}, true
+		}
+	}
+	return a, false
+}
+
+// LogValue implements slog.LogValuer, logging the masked pan only
+func (i *info) LogValue() (ret slog.Value) {
+	return slog.StringValue(i.Masked())
+}
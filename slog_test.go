@@ -0,0 +1,102 @@
+//go:build go1.21
+
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+type slogValuer string
+
+func (v slogValuer) LogValue() slog.Value {
+	return slog.StringValue(string(v))
+}
+
+func slogLogger(buf *bytes.Buffer, opts ...ScrubOption) (ret *slog.Logger) {
+	h := slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	return slog.New(NewScrubHandler(h, opts...))
+}
+
+func TestScrubHandler(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := slogLogger(buf, ScrubSkipKeys("raw"))
+
+	l.With("card", "4111111111111111", "order", "1234567890123456").
+		WithGroup("req").
+		Info("charge 5555555555554444",
+			"raw", "4111111111111111",
+			"amount", 4111111111111111,
+			slog.Group("payment",
+				"pan", "4111 1111 1111 1111",
+				slog.Group("history", "last", slogValuer("4012888888881881")),
+				"note", "none",
+			),
+		)
+	expect := `{"level":"INFO","msg":"charge 555555******4444",` +
+		`"card":"411111******1111","order":"1234567890123456",` +
+		`"req":{"raw":"4111111111111111","amount":4111111111111111,` +
+		`"payment":{"pan":"4111 11** **** 1111","history":{"last":"401288******1881"},"note":"none"}}}` + "\n"
+	if actual := buf.String(); actual != expect {
+		t.Log("expect:", expect)
+		t.Log("actual:", actual)
+		t.Fatal("unexpected result")
+	}
+}
+
+func TestScrubHandlerNoHit(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := slogLogger(buf)
+	l.Info("order 1234567890123456", "id", 1, slog.Group("g", "note", "4111111111111112"))
+
+	expect := `{"level":"INFO","msg":"order 1234567890123456","id":1,"g":{"note":"4111111111111112"}}` + "\n"
+	if actual := buf.String(); actual != expect {
+		t.Log("expect:", expect)
+		t.Log("actual:", actual)
+		t.Fatal("unexpected result")
+	}
+}
+
+func benchmarkSlog(b *testing.B, h slog.Handler) {
+	l := slog.New(h)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Info("order created", "order", "1234567890123456", "user", "alice",
+			slog.Group("req", "path", "/checkout", "status", 200))
+	}
+}
+
+func BenchmarkScrubHandlerNoHit(b *testing.B) {
+	benchmarkSlog(b, NewScrubHandler(slog.NewJSONHandler(io.Discard, nil)))
+}
+
+func BenchmarkScrubHandlerPassThrough(b *testing.B) {
+	benchmarkSlog(b, slog.NewJSONHandler(io.Discard, nil))
+}
+
+func TestInfoLogValue(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := slog.New(slog.NewTextHandler(buf, nil))
+	info, _ := FromRaw("4111111111111111")
+
+	l.Info("charge", "card", info)
+	if actual := buf.String(); strings.Contains(actual, "4111111111111111") ||
+		!strings.Contains(actual, "card=4111-11**-****-1111") {
+		t.Fatal("unexpected log:", actual)
+	}
+}
@@ -0,0 +1,204 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Summary is statistics of a card list, see Summarize
+//
+// Entries can be added one by one with Add, so it can be used on streams.
+// Zero value is ready to use.
+type Summary struct {
+	Total  int // number of entries
+	Valid  int // number of entries passed Validate
+	Masked int // number of parsed entries with masked digits
+	Full   int // number of parsed entries without masked digits
+
+	CardTypes map[CardType]int // number of parsed entries of each card type
+	Errors    map[string]int   // number of entries failed with each error, see summaryErrors
+	Lengths   map[int]int      // number of parsed entries of each pan length
+}
+
+// errors counted in Summary.Errors, keyed by Error() of the first one matched
+// by errors.Is, so wrapped or parameterized errors like ErrSectionValue share
+// same key. Other errors are counted as "other".
+var summaryErrors = []error{
+	ErrNoCard,
+	ErrNoDigits, // before ErrValidateMasked, see ErrPANFormat.Is
+	ErrValidateMasked,
+	ErrValidate,
+	ErrBrandLength,
+	ErrNotAccepted,
+	ErrBINDenied,
+	ErrImplausible,
+	ErrSection,
+	ErrRaw,
+	ErrMasked,
+	ErrMaskedPAN,
+	ErrUnmasked,
+}
+
+func errorCategory(err error) (ret string) {
+	for _, e := range summaryErrors {
+		if errors.Is(err, e) {
+			return e.Error()
+		}
+	}
+	return "other"
+}
+
+// Summarize computes statistics of infos
+func Summarize(infos []Info) (ret Summary) {
+	for _, info := range infos {
+		ret.Add(info)
+	}
+	return
+}
+
+// SummarizeStrings parses pans with Parse and computes statistics
+//
+// Parse errors are counted in Errors.
+func SummarizeStrings(pans []string) (ret Summary) {
+	for _, pan := range pans {
+		info, err := Parse(pan)
+		if err != nil {
+			ret.addError(err)
+			continue
+		}
+		ret.Add(info)
+	}
+	return
+}
+
+func (s *Summary) addError(err error) {
+	s.Total++
+	if s.Errors == nil {
+		s.Errors = map[string]int{}
+	}
+	s.Errors[errorCategory(err)]++
+}
+
+// Add adds an entry to the statistics
+//
+// nil and NoCard are counted as ErrNoCard.
+func (s *Summary) Add(info Info) {
+	if info == nil || info.IsZero() {
+		s.addError(ErrNoCard)
+		return
+	}
+
+	if err := info.Validate(); err != nil {
+		s.addError(err)
+	} else {
+		s.Total++
+		s.Valid++
+	}
+
+	if s.CardTypes == nil {
+		s.CardTypes = map[CardType]int{}
+	}
+	s.CardTypes[info.CardType()]++
+
+	pan := info.RawPAN()
+	if s.Lengths == nil {
+		s.Lengths = map[int]int{}
+	}
+	s.Lengths[len(pan)]++
+	if strings.Contains(pan, "*") {
+		s.Masked++
+	} else {
+		s.Full++
+	}
+}
+
+func (s Summary) brands() (ret map[string]int) {
+	ret = make(map[string]int, len(s.CardTypes))
+	for t, n := range s.CardTypes {
+		ret[t.String()] += n
+	}
+	return
+}
+
+// String renders s as a compact table
+func (s Summary) String() (ret string) {
+	buf := &strings.Builder{}
+	w := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+	row := func(name string, n int) {
+		w.Write([]byte(name + "\t" + strconv.Itoa(n) + "\n"))
+	}
+
+	row("total", s.Total)
+	row("valid", s.Valid)
+	row("masked", s.Masked)
+	row("full", s.Full)
+
+	brands := s.brands()
+	keys := make([]string, 0, len(brands))
+	for k := range brands {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		row("brand "+k, brands[k])
+	}
+
+	lengths := make([]int, 0, len(s.Lengths))
+	for l := range s.Lengths {
+		lengths = append(lengths, l)
+	}
+	sort.Ints(lengths)
+	for _, l := range lengths {
+		row("length "+strconv.Itoa(l), s.Lengths[l])
+	}
+
+	keys = keys[:0]
+	for k := range s.Errors {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		row("error "+k, s.Errors[k])
+	}
+
+	w.Flush()
+	return buf.String()
+}
+
+// MarshalJSON implements json.Marshaler
+//
+// Card types are keyed by CardType.String.
+func (s Summary) MarshalJSON() (ret []byte, err error) {
+	errs, lengths := s.Errors, s.Lengths
+	if errs == nil {
+		errs = map[string]int{}
+	}
+	if lengths == nil {
+		lengths = map[int]int{}
+	}
+	return json.Marshal(struct {
+		Total   int            `json:"total"`
+		Valid   int            `json:"valid"`
+		Masked  int            `json:"masked"`
+		Full    int            `json:"full"`
+		Brands  map[string]int `json:"brands"`
+		Errors  map[string]int `json:"errors"`
+		Lengths map[int]int    `json:"lengths"`
+	}{
+		Total:   s.Total,
+		Valid:   s.Valid,
+		Masked:  s.Masked,
+		Full:    s.Full,
+		Brands:  s.brands(),
+		Errors:  errs,
+		Lengths: lengths,
+	})
+}
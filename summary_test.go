@@ -0,0 +1,140 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func summaryInput(t *testing.T) (ret []Info) {
+	pans := []string{
+		"4111111111000066",
+		"4111111111000067",
+		"411111******0066",
+		"3528************",
+		"****************",
+		"0000000000000000",
+	}
+	for _, pan := range pans {
+		info, err := FromRaw(pan)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		ret = append(ret, info)
+	}
+	return append(ret, NoCard, nil)
+}
+
+func TestSummarize(t *testing.T) {
+	actual := Summarize(summaryInput(t))
+	expect := Summary{
+		Total:  8,
+		Valid:  2,
+		Masked: 3,
+		Full:   3,
+		CardTypes: map[CardType]int{
+			VISACard:        3,
+			JCBCard:         1,
+			UnknownCardType: 2,
+		},
+		Errors: map[string]int{
+			ErrValidate.Error():       1,
+			ErrValidateMasked.Error(): 2,
+			ErrNoDigits.Error():       1,
+			ErrNoCard.Error():         2,
+		},
+		Lengths: map[int]int{16: 6},
+	}
+	if !reflect.DeepEqual(actual, expect) {
+		t.Log("expect:", expect)
+		t.Log("actual:", actual)
+		t.Fatal("unexpected result")
+	}
+
+	buf, err := json.Marshal(actual)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	expectJSON := `{"total":8,"valid":2,"masked":3,"full":3,` +
+		`"brands":{"jcb":1,"unknown":2,"visa":3},` +
+		`"errors":{` +
+		`"creditcard: incorrect pan format: fully masked pan cannot be validated":1,` +
+		`"creditcard: incorrect pan format: invalid pan":1,` +
+		`"creditcard: incorrect pan format: masked pan cannot be validated":2,` +
+		`"creditcard: incorrect pan format: no card":2},` +
+		`"lengths":{"16":6}}`
+	if string(buf) != expectJSON {
+		t.Log("expect:", expectJSON)
+		t.Log("actual:", string(buf))
+		t.Fatal("unexpected json")
+	}
+}
+
+func TestSummarizeStrings(t *testing.T) {
+	actual := SummarizeStrings([]string{"4111-1111-1100-0066", "bad", "411111******0066"})
+	expect := Summary{
+		Total:     3,
+		Valid:     1,
+		Masked:    1,
+		Full:      1,
+		CardTypes: map[CardType]int{VISACard: 2},
+		Errors: map[string]int{
+			ErrRaw.Error():            1,
+			ErrValidateMasked.Error(): 1,
+		},
+		Lengths: map[int]int{16: 2},
+	}
+	if !reflect.DeepEqual(actual, expect) {
+		t.Log("expect:", expect)
+		t.Log("actual:", actual)
+		t.Fatal("unexpected result")
+	}
+}
+
+func TestSummaryString(t *testing.T) {
+	s := SummarizeStrings([]string{"4111111111000066", "bad"})
+	expect := "" +
+		"total                                                                                            2\n" +
+		"valid                                                                                            1\n" +
+		"masked                                                                                           0\n" +
+		"full                                                                                             1\n" +
+		"brand visa                                                                                       1\n" +
+		"length 16                                                                                        1\n" +
+		"error creditcard: incorrect pan format: raw pan must be digits or asterisks of supported length  1\n"
+	if actual := s.String(); actual != expect {
+		t.Log("expect:", expect)
+		t.Log("actual:", actual)
+		t.Fatal("unexpected result")
+	}
+
+	if actual := (Summary{}).String(); actual != "total   0\nvalid   0\nmasked  0\nfull    0\n" {
+		t.Log("actual:", actual)
+		t.Fatal("unexpected result of empty summary")
+	}
+}
+
+func TestSummaryErrorCategory(t *testing.T) {
+	var s Summary
+	s.addError(ErrSectionValue{Index: 1, Width: 4})
+	s.addError(fmt.Errorf("row 3: %w", ErrSectionValue{Index: 2, Width: 6}))
+	s.addError(newErrUnsupportedLength(3, variableLengths))
+	s.addError(newErrUnsupportedLength(21, variableLengths))
+	s.addError(errors.New("custom validator"))
+
+	expect := map[string]int{
+		ErrSection.Error(): 2,
+		ErrRaw.Error():     2,
+		"other":            1,
+	}
+	if !reflect.DeepEqual(s.Errors, expect) {
+		t.Log("expect:", expect)
+		t.Log("actual:", s.Errors)
+		t.Fatal("unexpected result")
+	}
+}
@@ -0,0 +1,37 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import "strings"
+
+// ErrTemplate is returned by FormatTemplate if number of digit positions in
+// the template differs from length of the PAN
+const ErrTemplate ErrArgument = "template must have a digit position for each digit of pan"
+
+func (i *info) FormatTemplate(tmpl string) (ret string, err error) {
+	if i.IsZero() {
+		return "", ErrNoCard
+	}
+	pan := i.RawPAN()
+	if strings.Count(tmpl, "#")+strings.Count(tmpl, "X") != len(pan) {
+		return "", ErrTemplate
+	}
+
+	buf := make([]byte, 0, len(tmpl))
+	idx := 0
+	for _, c := range []byte(tmpl) {
+		switch c {
+		case '#':
+			buf = append(buf, pan[idx])
+			idx++
+		case 'X':
+			buf = append(buf, '*')
+			idx++
+		default:
+			buf = append(buf, c)
+		}
+	}
+	return string(buf), nil
+}
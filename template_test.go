@@ -0,0 +1,44 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import "testing"
+
+func TestFormatTemplate(t *testing.T) {
+	cases := []struct {
+		pan    string
+		tmpl   string
+		expect string
+		err    error
+	}{
+		{"4111111111000066", "######XXXXXX####", "411111******0066", nil},
+		{"4111111111000066", "#### ##XX XXXX ####", "4111 11** **** 0066", nil},
+		{"4111111111000066", "################", "4111111111000066", nil},
+		{"4111111111000066", "XXXX-XXXX-XXXX-####", "****-****-****-0066", nil},
+		{"411111******0066", "################", "411111******0066", nil},
+		{"411111******0066", "########X#######", "411111******0066", nil},
+		{"411111******0066", "####XXXXXXXX####", "4111********0066", nil},
+		{"4111111111000066", "######XXXXXX###", "", ErrTemplate},
+		{"4111111111000066", "#### #### #### #### #", "", ErrTemplate},
+		{"4111111111000066", "", "", ErrTemplate},
+	}
+
+	for _, c := range cases {
+		t.Run(c.tmpl, func(t *testing.T) {
+			info, _ := Parse(c.pan)
+			actual, err := info.FormatTemplate(c.tmpl)
+			if err != c.err {
+				t.Log("expect:", c.err)
+				t.Log("actual:", err)
+				t.Fatal("unexpected error")
+			}
+			if actual != c.expect {
+				t.Log("expect:", c.expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+		})
+	}
+}
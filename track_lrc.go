@@ -0,0 +1,77 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+// Possible errors returned by TrackLRC and VerifyTrackLRC
+const (
+	ErrTrackEncoding  ErrTrack = "unknown track encoding"
+	ErrTrackCharacter ErrTrack = "character cannot be encoded"
+	ErrTrackLRC       ErrTrack = "lrc mismatch"
+)
+
+// TrackEncoding is the character encoding of a magnetic stripe track
+// (ISO 7811)
+type TrackEncoding int
+
+// Supported track encodings
+const (
+	// 6 data bits + parity, ascii 0x20-0x5F, used by track 1
+	Track1Encoding TrackEncoding = iota + 1
+	// 4 data bits + parity, ascii 0x30-0x3F, used by track 2 and 3
+	Track2Encoding
+)
+
+// base returns the ascii character of value 0 and number of values
+func (e TrackEncoding) base() (ret byte, n byte, err error) {
+	switch e {
+	case Track1Encoding:
+		return 0x20, 64, nil
+	case Track2Encoding:
+		return 0x30, 16, nil
+	}
+	return 0, 0, ErrTrackEncoding
+}
+
+// TrackLRC computes the longitudinal redundancy check character of data
+//
+// The LRC is the XOR of data bits of every character, so data should include
+// start and end sentinels, like ";4111111111111111=2512101?". Parity bit of
+// the LRC is up to the writer, the returned character is in ascii as the rest
+// of data.
+func TrackLRC(data string, encoding TrackEncoding) (ret byte, err error) {
+	base, n, err := encoding.base()
+	if err != nil {
+		return
+	}
+
+	lrc := byte(0)
+	for x := 0; x < len(data); x++ {
+		c := data[x]
+		if c < base || c-base >= n {
+			err = ErrTrackCharacter
+			return
+		}
+		lrc ^= c - base
+	}
+
+	return lrc + base, nil
+}
+
+// VerifyTrackLRC checks if last character of data is the correct LRC of the
+// rest, see TrackLRC
+func VerifyTrackLRC(data string, encoding TrackEncoding) (err error) {
+	if len(data) < 1 {
+		return ErrTrackLRC
+	}
+	l := len(data) - 1
+	lrc, err := TrackLRC(data[:l], encoding)
+	if err != nil {
+		return
+	}
+	if lrc != data[l] {
+		return ErrTrackLRC
+	}
+	return
+}
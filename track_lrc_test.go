@@ -0,0 +1,143 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTrackLRC(t *testing.T) {
+	cases := []struct {
+		data   string
+		enc    TrackEncoding
+		expect byte
+		err    error
+	}{
+		// ';'(0xB) ^ '1'(0x1) ^ '?'(0xF) = 0x5
+		{";1?", Track2Encoding, '5', nil},
+		// '%'(0x05) ^ 'B'(0x22) ^ '1'(0x11) ^ '?'(0x1F) = 0x29
+		{"%B1?", Track1Encoding, 'I', nil},
+		{";4111111111111111=2512101?", Track2Encoding, '8', nil},
+		{"%B4111111111111111^DOE/JOHN^2512101?", Track1Encoding, '+', nil},
+		{"", Track2Encoding, '0', nil},
+		{";4111^?", Track2Encoding, 0, ErrTrackCharacter},
+		{"%b1?", Track1Encoding, 0, ErrTrackCharacter},
+		{";1?", TrackEncoding(0), 0, ErrTrackEncoding},
+	}
+
+	for _, c := range cases {
+		t.Run(c.data, func(t *testing.T) {
+			actual, err := TrackLRC(c.data, c.enc)
+			if err != c.err {
+				t.Log("expect:", c.err)
+				t.Log("actual:", err)
+				t.Fatal("unexpected error")
+			}
+			if actual != c.expect {
+				t.Log("expect:", string(c.expect))
+				t.Log("actual:", string(actual))
+				t.Fatal("unexpected result")
+			}
+			if err != nil {
+				return
+			}
+
+			if err = VerifyTrackLRC(c.data+string(actual), c.enc); err != nil {
+				t.Fatal("unexpected verification error:", err)
+			}
+			if err = VerifyTrackLRC(c.data+string(actual^1), c.enc); err != ErrTrackLRC {
+				t.Log("expect:", ErrTrackLRC)
+				t.Log("actual:", err)
+				t.Fatal("unexpected verification result")
+			}
+		})
+	}
+}
+
+// TestTrackLRCStripe checks TrackLRC against bits recorded on the stripe
+//
+// Each frame is a character as defined in ISO 7811-2: data bits (b1 first)
+// followed by an odd parity bit. The last frame is the LRC, every data bit
+// column of the track including the LRC has even number of ones, and the LRC
+// has its own odd parity.
+func TestTrackLRCStripe(t *testing.T) {
+	cases := []struct {
+		enc    TrackEncoding
+		frames string
+	}{
+		{
+			// ;4111111111111111=2512101?8
+			Track2Encoding,
+			"11010 00100 10000 10000 10000 10000 10000 10000 10000 10000 10000 " +
+				"10000 10000 10000 10000 10000 10000 10110 01000 10101 10000 01000 " +
+				"10000 00001 10000 11111 00010",
+		},
+		{
+			// ;5555555555554444=3012?9
+			Track2Encoding,
+			"11010 10101 10101 10101 10101 10101 10101 10101 10101 10101 10101 " +
+				"10101 10101 00100 00100 00100 00100 10110 11001 00001 10000 01000 " +
+				"11111 10011",
+		},
+		{
+			// %B4111111111111111^DOE/JOHN^2512101?+
+			Track1Encoding,
+			"1010001 0100011 0010101 1000101 1000101 1000101 1000101 1000101 " +
+				"1000101 1000101 1000101 1000101 1000101 1000101 1000101 1000101 " +
+				"1000101 1000101 0111110 0010011 1111010 1010010 1111001 0101010 " +
+				"1111010 0001011 0111011 0111110 0100101 1010100 1000101 0100101 " +
+				"1000101 0000100 1000101 1111100 1101000",
+		},
+	}
+
+	for _, c := range cases {
+		frames := strings.Fields(c.frames)
+		base := byte(0x30)
+		if c.enc == Track1Encoding {
+			base = 0x20
+		}
+
+		// decode like a reader does
+		track := make([]byte, len(frames))
+		columns := make([]int, len(frames[0])-1)
+		for idx, f := range frames {
+			ones, v := 0, byte(0)
+			for bit := 0; bit < len(f); bit++ {
+				if f[bit] == '0' {
+					continue
+				}
+				ones++
+				if bit < len(columns) {
+					v |= 1 << bit
+					columns[bit]++
+				}
+			}
+			if ones%2 != 1 {
+				t.Fatalf("parity error in frame %d of %s", idx, c.frames)
+			}
+			track[idx] = base + v
+		}
+		for bit, ones := range columns {
+			if ones%2 != 0 {
+				t.Fatalf("bad test data: column %d of %s", bit, track)
+			}
+		}
+
+		data, expect := string(track[:len(track)-1]), track[len(track)-1]
+		actual, err := TrackLRC(data, c.enc)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if actual != expect {
+			t.Log("expect:", string(expect))
+			t.Log("actual:", string(actual))
+			t.Fatal("unexpected lrc of", data)
+		}
+		if err = VerifyTrackLRC(string(track), c.enc); err != nil {
+			t.Fatal("unexpected verification error:", err)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+// card type of Infos created by ParseTrusted, it is detected when needed
+const pendingCardType CardType = -2
+
+// ParseTrusted creates Info from canonical form (see Info.Canonical) without
+// checking it, UNSAFE for untrusted input
+//
+// It is a fast path for PANs already validated by Parse, like those passed
+// between internal services. Characters are not checked, and card type is
+// detected only when needed. The result is undefined if s is not digits or
+// asterisks, NoCard is returned if its length is not supported by FromRaw.
+//
+// Build with tag "creditcard_checked" to check the input by Parse instead,
+// and panic if it is invalid, which is useful in test environments.
+func ParseTrusted(s string) (ret Info) {
+	if trustedChecked {
+		info, err := Parse(s)
+		if err != nil {
+			panic("creditcard: untrusted input of ParseTrusted: " + err.Error())
+		}
+		return info
+	}
+
+	if layouts(len(s), variableLengths) == nil {
+		return NoCard
+	}
+	return &info{
+		pan: s,
+		typ: pendingCardType,
+	}
+}
@@ -0,0 +1,18 @@
+//go:build creditcard_checked
+
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import "testing"
+
+func TestParseTrustedChecked(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("invalid input should panic in checked mode")
+		}
+	}()
+	ParseTrusted("4111-1111-11a0-0066")
+}
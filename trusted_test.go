@@ -0,0 +1,68 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import "testing"
+
+func TestParseTrusted(t *testing.T) {
+	pans := []string{
+		"4111111111000066",
+		"4111111111111111",
+		"5555555555554444",
+		"3530111333300000",
+		"6212345678901234",
+		"9999999999999999",
+		"411111******0066",
+		"****************",
+	}
+	for _, pan := range pans {
+		t.Run(pan, func(t *testing.T) {
+			expect, err := FromRaw(pan)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			actual := ParseTrusted(pan)
+			for _, f := range []func(Info) interface{}{
+				func(x Info) interface{} { return x.CardType() },
+				func(x Info) interface{} { return x.Validate() },
+				func(x Info) interface{} { return x.AuditString() },
+				func(x Info) interface{} { return x.Masked() },
+				func(x Info) interface{} { return x.PAN() },
+				func(x Info) interface{} { return x.Canonical() },
+				func(x Info) interface{} { return x.IsFullyMasked() },
+			} {
+				if e, a := f(expect), f(actual); e != a {
+					t.Log("expect:", e)
+					t.Log("actual:", a)
+					t.Fatal("unexpected result")
+				}
+			}
+
+			if x, _ := actual.WithSection(0, "5555"); x.CardType() != MasterCard {
+				t.Fatal("card type is not detected after WithSection:", x.CardType())
+			}
+		})
+	}
+
+	if !trustedChecked && ParseTrusted("4111") != NoCard {
+		t.Fatal("unexpected result of short input")
+	}
+}
+
+var trustedSink Info
+
+func BenchmarkParseTrusted(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		trustedSink = ParseTrusted("4111111111000066")
+	}
+}
+
+func BenchmarkParseTrustedFromRaw(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		trustedSink, _ = FromRaw("4111111111000066")
+	}
+}
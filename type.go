@@ -5,6 +5,8 @@
 package creditcard
 
 // CardType denotes a card issuer, only few are supported.
+//
+// It is encoded as its name in JSON and other text formats, see String.
 type CardType int
 
 // Supported card issuers
@@ -17,6 +19,24 @@ const (
 	JCBCard                     // JCB
 	AmericanExpress             // American Express
 	UnionPay                    // China UnionPay
+	DinersClub                  // Diners Club
+	MaestroCard                 // Maestro
+	MirCard                     // Mir
+	RuPayCard                   // RuPay
+	EloCard                     // Elo
+	HipercardCard               // Hipercard
+	DankortCard                 // Dankort, see WithDankortCoBadge
+	TroyCard                    // Troy
+	VerveCard                   // Verve
+	UATPCard                    // UATP, airline cards
+	InstaPaymentCard            // InstaPayment
+	VisaElectron                // Visa Electron, see CardType.Family
+	CarteBancaire               // Cartes Bancaires, see WithCarteBancaire
+	Forbrugsforeningen          // Forbrugsforeningen
+	UzCard                      // UzCard
+	HumoCard                    // Humo
+	NapasCard                   // Napas
+	ChinaTUnion                 // China T-Union
 	endKnownCardType
 )
 
@@ -31,6 +51,31 @@ func asCardType(t CardType) (ret CardType) {
 	return t
 }
 
+// Known reports if t is a supported card issuer, including ones registered by
+// RegisterMatcher of any Config, that is, if t is one of AllCardTypes
+func (t CardType) Known() (ret bool) {
+	if t >= firstRegisteredCardType {
+		return int(t-firstRegisteredCardType) < len(currentNames())
+	}
+	return t > beginKnownCardType && t < endKnownCardType
+}
+
+// IsKnown is an alias of Known, reports if t is one of AllCardTypes
+func (t CardType) IsKnown() (ret bool) { return t.Known() }
+
+// AllCardTypes returns supported card issuers, ordered by their values
+//
+// UnknownCardType is not included. The returned slice is newly allocated on
+// each call, modifying it does not affect this package.
+//
+// The order is same as the constants above, so it is stable. It is derived
+// from the table used to detect card types, so it is always in sync with
+// detection. Card types registered by RegisterMatcher follow built-in ones,
+// in registration order.
+func AllCardTypes() (ret []CardType) {
+	return append(builtinTypes(), registeredTypes()...)
+}
+
 // ErrPANFormat indicates there's something wrong with PAN numbers
 type ErrPANFormat string
 
@@ -38,33 +83,177 @@ func (e ErrPANFormat) Error() (ret string) {
 	return "creditcard: incorrect pan format: " + string(e)
 }
 
+// Is reports ErrNoDigits as ErrValidateMasked, so errors.Is(err,
+// ErrValidateMasked) holds for every masked PAN
+func (e ErrPANFormat) Is(target error) (ret bool) {
+	return e == ErrNoDigits && target == ErrValidateMasked
+}
+
 // Possible errors returned by this package
 const (
-	ErrSection        ErrPANFormat = "there must be 4 sections in PAN, each section must be 4 digits"
-	ErrRaw            ErrPANFormat = "raw pan must be 16 digits or asterisks"
+	ErrSection        ErrPANFormat = "pan must be at most 4 sections of up to 4 digits or asterisks, or groups of a supported layout like 4-6-5"
+	ErrRaw            ErrPANFormat = "raw pan must be digits or asterisks of supported length"
 	ErrMasked         ErrPANFormat = "masked pan must be first 6 digits and last 4 digits"
 	ErrValidateMasked ErrPANFormat = "masked pan cannot be validated"
+	ErrNoDigits       ErrPANFormat = "fully masked pan cannot be validated"
 	ErrValidate       ErrPANFormat = "invalid pan"
+	ErrMaskedPAN      ErrPANFormat = "operation requires unmasked pan"
 )
 
+// ErrArgument indicates an invalid argument is passed to helpers
+type ErrArgument string
+
+func (e ErrArgument) Error() (ret string) {
+	return "creditcard: invalid argument: " + string(e)
+}
+
 // Info is the main interface to acces helpers in this package
+//
+// Group separator of PAN, Masked, FullFirst6 and FullLast4 is "-" by default,
+// it can be changed by SetDefaultSeparator, or by the Config creating it.
+// Examples below are 16-digit PANs. Digits of other lengths are grouped like
+// what is printed on the card, see FromSlice.
 type Info interface {
+	// reports if it is NoCard or zero value
+	//
+	// Methods of such Info do not panic, they behave like NoCard.
+	IsZero() (ret bool)
 	CardType() (ret CardType)
 	Checksum() (ret string)   // returns last digit
 	Last4() (ret string)      // returns "3456"
 	First6() (ret string)     // returns "123456"
-	FullLast4() (ret string)  // returns "****-****-****-3456"
-	FullFirst6() (ret string) // returns "1234-56**-****-****"
-	RawMasked() (ret string)  // returns "123456******1234"
-	Masked() (ret string)     // returns "1234-56**-****-1234"
+	FullLast4() (ret string)  // returns "****-****-****-3456", lossy
+	FullFirst6() (ret string) // returns "1234-56**-****-****", lossy
+	RawMasked() (ret string)  // returns "123456******1234", lossy
+	Masked() (ret string)     // returns "1234-56**-****-1234", lossy
 	RawPAN() (ret string)     // returns "1234567890123456"
 	PAN() (ret string)        // returns "1234-5678-9012-3456"
+	// renders the PAN with a processor-style template like "#### ##XX XXXX ####"
+	//
+	// Each "#" is replaced by a digit and each "X" by "*", other characters
+	// are copied verbatim. Masked digits are rendered as "*" even at "#". It
+	// returns ErrTemplate if number of "#" and "X" differs from length of the
+	// PAN.
+	FormatTemplate(tmpl string) (ret string, err error)
+	// returns the canonical form like "1234567890123456" or "123456******1234"
+	//
+	// It is digits with masked digits rendered as "*", without separators.
+	// c.Parse(x.Canonical()) returns an Info with same digits, length, mask
+	// positions and card type as x, where c is the Config that created x, so
+	// it is the recommended form to persist. Other Configs may detect another
+	// card type (see WithMatcher). The grouping of x (like 6-13 passed to
+	// FromSlice), pan sequence number and issue number are not kept.
+	// Methods marked as lossy drop digits and cannot be used to restore x.
+	Canonical() (ret string)
 	// returns ErrValidateMasked if pan is masked, ErrValidate if pan is
 	// invalid, or nil if pan is valid
+	//
+	// ErrNoDigits is returned instead if no digit is known (see
+	// IsFullyMasked), errors.Is(ErrNoDigits, ErrValidateMasked) is true.
 	Validate() (err error)
+	// reports if every digit is masked, like FromSlice(nil)
+	IsFullyMasked() (ret bool)
+	// returns number of characters fixed by WithOCRCorrections when parsing
+	//
+	// Non-zero means the PAN is repaired, Validate should pass before
+	// trusting it.
+	Corrections() (ret int)
+	// checks if exposed digits of a masked PAN follow one of policies
+	//
+	// Policies default to MaskFirst6Last4, MaskFirst8Last4 and MaskLast4. It
+	// returns ErrUnmasked if no digit is masked, or ErrMaskFormat naming
+	// positions of exposed digits which violate the closest policy.
+	ValidateMaskFormat(policies ...MaskPolicy) (err error)
+	// returns all luhn-valid PANs matching a masked PAN, SENSITIVE
+	//
+	// It reconstructs full PANs, so the result must be handled like raw PANs
+	// and never be logged. It refuses to run and returns ErrCompletionLimit
+	// if number of candidates (10 to the power of masked digits) exceeds
+	// limit.
+	EnumerateCompletions(limit int) (ret []Info, err error)
+	// encodes the PAN as an integer, see DecodeUint64
+	//
+	// The value is 10^len(PAN) + PAN, so leading zeros and length are kept.
+	// Every PAN up to 18 digits can be encoded, but 19-digit PANs greater
+	// than 8446744073709551615 cannot since the value overflows, in which
+	// case ErrUint64Overflow is returned. It returns ErrMaskedPAN if the PAN
+	// is masked.
+	EncodeUint64() (ret uint64, err error)
+	// returns a copy with PAN sequence number (EMV tag 5F34) attached
+	//
+	// It returns ErrSequence if n is not in 0-99.
+	WithSequence(n int) (ret Info, err error)
+	// returns a copy with the group at index (0-3 if 16 digits) replaced by
+	// value
+	//
+	// Value must have same width as the group, composed by digits or
+	// asterisks. Card type is detected again if the group overlaps first 8
+	// digits, which are used to detect card type. It
+	// returns ErrSectionValue if index or value is invalid.
+	WithSection(index int, value string) (ret Info, err error)
+	// returns PAN sequence number, ok is false if it is not attached
+	Sequence() (ret int, ok bool)
+	// returns a copy with issue number of legacy UK debit cards (Switch,
+	// Solo) attached
+	//
+	// It returns ErrIssueNumber if n is not in 0-99.
+	WithIssueNumber(n int) (ret Info, err error)
+	// returns issue number, ok is false if it is not attached
+	IssueNumber() (ret int, ok bool)
+	// returns PCI-safe summary line like "VISA|411111|1111|16|valid", lossy
+	//
+	// The format is stable and can be relied on by log parsers. It is
+	// composed by 5 fields separated by "|":
+	//
+	//   - brand: a fixed upper-case name of each built-in card type in
+	//     AllCardTypes, like VISA, MASTERCARD or AMEX, or UNKNOWN for
+	//     UnknownCardType and types registered by RegisterMatcher
+	//   - first 6 digits, masked digits are rendered as "*"
+	//   - last 4 digits, masked digits are rendered as "*"
+	//   - length of the PAN
+	//   - validation result: "valid", "invalid" or "masked"
+	AuditString() (ret string)
+	// returns a stable shard index in [0, n) derived from the PAN
+	//
+	// The construction is fixed so it can be reimplemented in other
+	// languages: compute HMAC-SHA256 of RawPAN() with key, take first 8
+	// bytes of the MAC as big-endian uint64, and the result is that value
+	// modulo n. It returns ErrMaskedPAN if the PAN is masked, or
+	// ErrShardCount if n < 1.
+	ShardKey(n int, key []byte) (ret int, err error)
+	// returns a fake PAN with same first 6 digits, last 4 digits and length
+	//
+	// Other digits are derived from HMAC-SHA256 of the PAN with key, so the
+	// result is deterministic. The result passes luhn check, and differs
+	// from the PAN. It returns ErrMaskedPAN if the PAN is masked.
+	Anonymize(key []byte) (ret Info, err error)
+	// returns a stable identifier for analytics like "card_..."
+	//
+	// It is PseudonymPrefix followed by first 16 bytes of HMAC-SHA256 of
+	// RawPAN() with key, encoded in lower-case base32 without padding. It
+	// cannot be reversed and does not look like a PAN, so it can be stored
+	// and joined across datasets instead of the PAN. The MAC is same as
+	// Fingerprint, only the format differs. It returns ErrMaskedPAN if the
+	// PAN is masked.
+	Pseudonym(key []byte) (ret string, err error)
 }
 
 type info struct {
-	pan [4]string
-	typ CardType
+	pan      string // digits or asterisks, see panLayouts
+	typ      CardType
+	seq      int     // pan sequence number
+	hasSeq   bool    // seq is set
+	issue    int     // issue number
+	hasIssue bool    // issue is set
+	cfg      *Config // nil means default config
+	layout   int     // index of panLayouts[len(pan)], not kept by Canonical
+
+	corrections int // see WithOCRCorrections
+}
+
+func (i *info) config() (ret *Config) {
+	if i == nil || i.cfg == nil {
+		return defaultConfig
+	}
+	return i.cfg
 }
@@ -0,0 +1,53 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"math"
+	"strconv"
+)
+
+// Possible errors returned by EncodeUint64 and DecodeUint64
+const (
+	ErrUint64Overflow ErrArgument = "pan is too large to be encoded as uint64"
+	ErrUint64Value    ErrArgument = "value is not an encoded pan of given length"
+)
+
+func (i *info) EncodeUint64() (ret uint64, err error) {
+	if i.IsZero() {
+		return 0, ErrNoCard
+	}
+
+	ret = 1
+	for _, c := range []byte(i.RawPAN()) {
+		if c == '*' {
+			return 0, ErrMaskedPAN
+		}
+		d := uint64(c - '0')
+		if ret > (math.MaxUint64-d)/10 {
+			return 0, ErrUint64Overflow
+		}
+		ret = ret*10 + d
+	}
+	return
+}
+
+// DecodeUint64 creates Info instance from the value returned by
+// Info.EncodeUint64
+//
+// It returns ErrUint64Value if v is not an encoded PAN of length digits.
+func DecodeUint64(v uint64, length int) (ret Info, err error) {
+	return defaultConfig.DecodeUint64(v, length)
+}
+
+// DecodeUint64 is same as package-level DecodeUint64, but uses c
+func (c *Config) DecodeUint64(v uint64, length int) (ret Info, err error) {
+	str := strconv.FormatUint(v, 10)
+	if len(str) != length+1 || str[0] != '1' {
+		err = ErrUint64Value
+		return
+	}
+	return c.fromRaw(str[1:])
+}
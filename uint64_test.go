@@ -0,0 +1,107 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeUint64(t *testing.T) {
+	cases := map[string]uint64{
+		"4111111111111111": 14111111111111111,
+		"0000000000000019": 10000000000000019,
+		"0000000000000000": 10000000000000000,
+		"9999999999999999": 19999999999999999,
+	}
+
+	for pan, expect := range cases {
+		t.Run(pan, func(t *testing.T) {
+			info, _ := FromRaw(pan)
+			actual, err := info.EncodeUint64()
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if actual != expect {
+				t.Log("expect:", expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+		})
+	}
+
+	masked, _ := FromMasked("411111", "1111")
+	if _, err := masked.EncodeUint64(); err != ErrMaskedPAN {
+		t.Log("expect:", ErrMaskedPAN)
+		t.Log("actual:", err)
+		t.Fatal("unexpected error")
+	}
+}
+
+func TestEncodeUint64RoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for x := 0; x < 10000; x++ {
+		info := randomInfo(t, r, false)
+		if x%10 == 0 {
+			// leading zeros
+			info, _ = FromRaw("000" + info.RawPAN()[3:])
+		}
+		v, err := info.EncodeUint64()
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		actual, err := DecodeUint64(v, len(info.RawPAN()))
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if !reflect.DeepEqual(actual, info) {
+			t.Log("expect:", info.RawPAN())
+			t.Log("actual:", actual.RawPAN())
+			t.Fatal("unexpected result")
+		}
+	}
+}
+
+func TestDecodeUint64(t *testing.T) {
+	cases := []struct {
+		v      uint64
+		length int
+	}{
+		{14111111111111111, 15},
+		{14111111111111111, 17},
+		{4111111111111111, 16},
+		{24111111111111111, 16},
+		{0, 0},
+	}
+	for _, c := range cases {
+		if _, err := DecodeUint64(c.v, c.length); err != ErrUint64Value {
+			t.Log("expect:", ErrUint64Value)
+			t.Log("actual:", err)
+			t.Fatal("unexpected error for", c.v, c.length)
+		}
+	}
+}
+
+func TestEncodeUint64Overflow(t *testing.T) {
+	max, err := variableConfig.FromRaw("8446744073709551615")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	v, err := max.EncodeUint64()
+	if err != nil || v != 18446744073709551615 {
+		t.Fatal("unexpected result:", v, err)
+	}
+
+	over, err := variableConfig.FromRaw("8446744073709551616")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, err = over.EncodeUint64(); err != ErrUint64Overflow {
+		t.Log("expect:", ErrUint64Overflow)
+		t.Log("actual:", err)
+		t.Fatal("unexpected error")
+	}
+}
@@ -0,0 +1,232 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"errors"
+	"strings"
+)
+
+// Possible errors returned by validators
+const (
+	ErrBrandLength ErrPANFormat = "pan length is invalid for the card brand"
+	ErrNotAccepted ErrPANFormat = "card brand is not accepted"
+	ErrBINDenied   ErrPANFormat = "bin is denied"
+	ErrImplausible ErrPANFormat = "pan is unlikely to be issued"
+)
+
+// Validator checks an Info
+type Validator interface {
+	Validate(info Info) (err error)
+}
+
+// ValidatorFunc is an adapter to use a function as Validator
+type ValidatorFunc func(info Info) (err error)
+
+// Validate implements Validator
+func (f ValidatorFunc) Validate(info Info) (err error) {
+	return f(info)
+}
+
+type luhnValidator struct{}
+
+func (luhnValidator) Validate(info Info) (err error) {
+	return validateChecksum(info, checkLuhn)
+}
+
+// validateChecksum checks if info is unmasked, and its check digit by rule
+func validateChecksum(info Info, rule ChecksumRule) (err error) {
+	if info == nil || info.IsZero() {
+		return ErrNoCard
+	}
+	if info.IsFullyMasked() {
+		return ErrNoDigits
+	}
+	pan := info.RawPAN()
+	if strings.Index(pan, "*") != -1 {
+		return ErrValidateMasked
+	}
+	if !rule(pan) {
+		return ErrValidate
+	}
+
+	return
+}
+
+// checkLuhn checks the check digit of pan, which must be digits
+//
+// Every second digit is doubled, starting from the one left to the check
+// digit.
+func checkLuhn(pan string) (ret bool) {
+	return luhnValid([]byte(pan))
+}
+
+// Luhn validates the check digit, it is what Info.Validate does unless the
+// rule is changed by SetValidationRule
+//
+// It returns ErrValidateMasked if the PAN is masked (ErrNoDigits if fully
+// masked), or ErrValidate if the check digit is incorrect.
+var Luhn Validator = luhnValidator{}
+
+type brandLengthValidator struct{}
+
+func (brandLengthValidator) Validate(info Info) (err error) {
+	if info == nil || info.IsZero() {
+		return ErrNoCard
+	}
+	t := info.CardType()
+	if _, ok := brands[t]; !ok {
+		return
+	}
+	if !t.IsValidLength(len(info.RawPAN())) {
+		return ErrBrandLength
+	}
+	return
+}
+
+// ValidPANLengths returns lengths of PAN issued by t, in ascending order
+//
+// It returns nil for unknown card types and ones registered by
+// RegisterMatcher. The returned slice is newly allocated on each call.
+func (t CardType) ValidPANLengths() (ret []int) {
+	if b, ok := brands[t]; ok {
+		ret = append([]int(nil), b.lengths...)
+	}
+	return
+}
+
+// IsValidLength reports if t issues PANs of n digits, see ValidPANLengths
+func (t CardType) IsValidLength(n int) (ret bool) {
+	for _, x := range brands[t].lengths {
+		if x == n {
+			return true
+		}
+	}
+	return
+}
+
+// BrandLength checks if length of the PAN is valid for its brand, like 15
+// digits for American Express
+//
+// PANs of unknown brand always pass. Masked PANs are validated too.
+var BrandLength Validator = brandLengthValidator{}
+
+type plausibilityValidator struct{}
+
+func (plausibilityValidator) Validate(info Info) (err error) {
+	if info == nil || info.IsZero() {
+		return ErrNoCard
+	}
+	if !info.CardType().Known() {
+		return ErrImplausible
+	}
+
+	pan := info.RawPAN()
+	account := pan[6 : len(pan)-1]
+	if strings.Contains(account, "*") {
+		return
+	}
+	if repeatedDigits(account) || sequentialDigits(account) {
+		return ErrImplausible
+	}
+	return
+}
+
+// repeatedDigits reports if s is composed by same digit, like "000000000"
+func repeatedDigits(s string) (ret bool) {
+	return strings.Count(s, s[:1]) == len(s)
+}
+
+// sequentialDigits reports if every digit in s is next (or previous) one of
+// the digit before it, like "234567890" or "987654321"
+func sequentialDigits(s string) (ret bool) {
+	up, down := true, true
+	for idx := 1; idx < len(s); idx++ {
+		d := (s[idx] - s[idx-1] + 10) % 10
+		up = up && d == 1
+		down = down && d == 9
+	}
+	return up || down
+}
+
+// Plausibility checks if the PAN looks like one issued to a real card, which
+// is useful to filter out made-up numbers
+//
+// It returns ErrImplausible if the brand is unknown, or account digits
+// (between first 6 digits and the check digit) are all same digit, like
+// 4111111111111111, or sequential, like 4111112345678903. The check digit is
+// not checked, chain it with Luhn. Masked account digits always pass.
+var Plausibility Validator = plausibilityValidator{}
+
+// Acceptance creates a Validator which accepts only specified brands
+//
+// Sub-brands are accepted if their family is specified, see CardType.Family.
+// It returns ErrNotAccepted for other brands, including UnknownCardType if
+// not specified.
+func Acceptance(types ...CardType) (ret Validator) {
+	accepted := make(map[CardType]bool, len(types))
+	for _, t := range types {
+		accepted[t] = true
+	}
+	return ValidatorFunc(func(info Info) (err error) {
+		if info == nil || info.IsZero() {
+			return ErrNoCard
+		}
+		if t := info.CardType(); !accepted[t] && !accepted[t.Family()] {
+			return ErrNotAccepted
+		}
+		return
+	})
+}
+
+// BINDenylist creates a Validator which rejects PANs starting with any of
+// prefixes
+//
+// It returns ErrBINDenied if the PAN is denied. Masked digits never match.
+func BINDenylist(prefixes ...string) (ret Validator) {
+	return ValidatorFunc(func(info Info) (err error) {
+		if info == nil || info.IsZero() {
+			return ErrNoCard
+		}
+		pan := info.RawPAN()
+		for _, p := range prefixes {
+			if strings.HasPrefix(pan, p) {
+				return ErrBINDenied
+			}
+		}
+		return
+	})
+}
+
+type chain struct {
+	validators []Validator
+	first      bool
+}
+
+func (c chain) Validate(info Info) (err error) {
+	var errs []error
+	for _, v := range c.validators {
+		if e := v.Validate(info); e != nil {
+			if c.first {
+				return e
+			}
+			errs = append(errs, e)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Chain creates a Validator which runs validators in order, and returns all
+// errors joined by errors.Join
+//
+// Use errors.Is to check for specific error.
+func Chain(validators ...Validator) (ret Validator) {
+	return chain{validators: validators}
+}
+
+// ChainFirstError is same as Chain, but stops at first error and returns it
+func ChainFirstError(validators ...Validator) (ret Validator) {
+	return chain{validators: validators, first: true}
+}
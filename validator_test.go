@@ -0,0 +1,157 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package creditcard
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestValidators(t *testing.T) {
+	visa, _ := FromRaw("4111111111000066")
+	invalid, _ := FromRaw("4111111111000067")
+	amex, _ := FromRaw("3782822463100050")
+	masked, _ := FromMasked("411111", "1111")
+	repeated, _ := FromRaw("4111111111111111")
+	ascending, _ := FromRaw("4111112345678903")
+	descending, _ := FromRaw("5500009876543214")
+	unknown, _ := FromRaw("9111111111000066")
+
+	cases := []struct {
+		name   string
+		v      Validator
+		info   Info
+		expect error
+	}{
+		{"luhn", Luhn, visa, nil},
+		{"luhn_invalid", Luhn, invalid, ErrValidate},
+		{"luhn_masked", Luhn, masked, ErrValidateMasked},
+		{"luhn_nocard", Luhn, NoCard, ErrNoCard},
+		{"length", BrandLength, visa, nil},
+		{"length_masked", BrandLength, masked, nil},
+		{"length_amex", BrandLength, amex, ErrBrandLength},
+		{"accept", Acceptance(VISACard, MasterCard), visa, nil},
+		{"accept_amex", Acceptance(VISACard, MasterCard), amex, ErrNotAccepted},
+		{"denylist", BINDenylist("5", "378282"), visa, nil},
+		{"denylist_amex", BINDenylist("5", "378282"), amex, ErrBINDenied},
+		{"denylist_masked", BINDenylist("4111111"), masked, nil},
+		{"plausible", Plausibility, visa, nil},
+		{"plausible_amex", Plausibility, amex, nil},
+		{"plausible_masked", Plausibility, masked, nil},
+		{"plausible_repeated", Plausibility, repeated, ErrImplausible},
+		{"plausible_ascending", Plausibility, ascending, ErrImplausible},
+		{"plausible_descending", Plausibility, descending, ErrImplausible},
+		{"plausible_unknown", Plausibility, unknown, ErrImplausible},
+		{"plausible_nocard", Plausibility, NoCard, ErrNoCard},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := c.v.Validate(c.info); actual != c.expect {
+				t.Log("expect:", c.expect)
+				t.Log("actual:", actual)
+				t.Fatal("unexpected result")
+			}
+		})
+	}
+
+	if visa.Validate() != Luhn.Validate(visa) || invalid.Validate() != Luhn.Validate(invalid) {
+		t.Fatal("Info.Validate differs from Luhn")
+	}
+}
+
+func TestChain(t *testing.T) {
+	amex, _ := FromRaw("3782822463100051")
+	var called []string
+	record := func(name string, v Validator) (ret Validator) {
+		return ValidatorFunc(func(info Info) (err error) {
+			called = append(called, name)
+			return v.Validate(info)
+		})
+	}
+	validators := []Validator{
+		record("luhn", Luhn),
+		record("length", BrandLength),
+		record("accept", Acceptance(AmericanExpress)),
+		record("deny", BINDenylist("37")),
+	}
+
+	err := Chain(validators...).Validate(amex)
+	for _, e := range []error{ErrValidate, ErrBrandLength, ErrBINDenied} {
+		if !errors.Is(err, e) {
+			t.Log("expect:", e)
+			t.Log("actual:", err)
+			t.Fatal("error is not aggregated")
+		}
+	}
+	if errors.Is(err, ErrNotAccepted) || len(called) != 4 {
+		t.Fatal("unexpected result:", err, called)
+	}
+
+	called = nil
+	err = ChainFirstError(validators...).Validate(amex)
+	if err != ErrValidate || len(called) != 1 {
+		t.Fatal("chain does not stop at first error:", err, called)
+	}
+
+	visa, _ := FromRaw("4111111111000066")
+	if err = Chain(Luhn, BrandLength).Validate(visa); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestValidPANLengths(t *testing.T) {
+	cases := []struct {
+		typ    CardType
+		expect []int
+	}{
+		{VISACard, []int{13, 16, 19}},
+		{MasterCard, []int{16}},
+		{AmericanExpress, []int{15}},
+		{DinersClub, []int{14, 16, 19}},
+		{MaestroCard, []int{12, 13, 14, 15, 16, 17, 18, 19}},
+		{UnionPay, []int{16, 17, 18, 19}},
+		{UnknownCardType, nil},
+		{endKnownCardType, nil},
+	}
+	for _, c := range cases {
+		if actual := c.typ.ValidPANLengths(); !reflect.DeepEqual(actual, c.expect) {
+			t.Log("expect:", c.expect)
+			t.Log("actual:", actual)
+			t.Fatal("unexpected result of", c.typ)
+		}
+	}
+
+	for _, typ := range AllCardTypes() {
+		lengths := typ.ValidPANLengths()
+		if len(lengths) == 0 || !sort.IntsAreSorted(lengths) {
+			t.Fatal("unexpected lengths of", typ, lengths)
+		}
+		for l := 0; l <= 20; l++ {
+			valid := false
+			for _, x := range lengths {
+				valid = valid || x == l
+			}
+			if typ.IsValidLength(l) != valid {
+				t.Fatal("unexpected result of", typ, l)
+			}
+			if valid && panLayouts[l] == nil {
+				t.Fatal("length of", typ, "cannot be constructed:", l)
+			}
+		}
+	}
+
+	// modifying returned slice does not affect later calls
+	lengths := VISACard.ValidPANLengths()
+	lengths[0] = 1
+	if !VISACard.IsValidLength(13) || VISACard.IsValidLength(1) {
+		t.Fatal("internal data is modified")
+	}
+	if UnknownCardType.IsValidLength(16) {
+		t.Fatal("unexpected valid length of unknown card type")
+	}
+}
@@ -0,0 +1,105 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+// Package zapcard provides zap integration of creditcard package
+//
+// It lives in its own module so the main package stays dependency-free.
+package zapcard
+
+import (
+	"strings"
+
+	"github.com/raohwork/creditcard"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type card struct {
+	info creditcard.Info
+}
+
+func (c card) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	brand := strings.SplitN(c.info.AuditString(), "|", 2)[0]
+	enc.AddString("pan", c.info.Masked())
+	enc.AddString("brand", strings.ToLower(brand))
+	enc.AddString("last4", c.info.Last4())
+	return
+}
+
+// Field creates a zap field which logs masked PAN, brand and last 4 digits of
+// info as an object, like {"pan":"4111-11**-****-1111","brand":"visa",
+// "last4":"1111"}
+//
+// Raw PAN is never logged. Nil or zero info (like creditcard.NoCard) is
+// skipped.
+func Field(key string, info creditcard.Info) (ret zap.Field) {
+	if info == nil || info.IsZero() {
+		return zap.Skip()
+	}
+	return zap.Object(key, card{info: info})
+}
+
+type core struct {
+	zapcore.Core
+	s *creditcard.Scrubber
+}
+
+// WrapCore wraps c so PAN-like sequences in message and string fields are
+// masked before written, see creditcard.Redact
+//
+// Level checking and sampling of c are preserved, fields of other types are
+// passed as-is.
+func WrapCore(c zapcore.Core, opts ...creditcard.ScrubOption) (ret zapcore.Core) {
+	return &core{Core: c, s: creditcard.NewScrubber(opts...)}
+}
+
+func (c *core) fields(fields []zapcore.Field) (ret []zapcore.Field) {
+	ret = fields
+	for idx, f := range fields {
+		if f.Type != zapcore.StringType || c.s.SkipKey(f.Key) {
+			continue
+		}
+		s := c.s.Scrub(f.String)
+		if s == f.String {
+			continue
+		}
+
+		if &ret[0] == &fields[0] {
+			ret = make([]zapcore.Field, len(fields))
+			copy(ret, fields)
+		}
+		ret[idx].String = s
+	}
+	return
+}
+
+func (c *core) With(fields []zapcore.Field) (ret zapcore.Core) {
+	return &core{Core: c.Core.With(c.fields(fields)), s: c.s}
+}
+
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) (ret *zapcore.CheckedEntry) {
+	// let wrapped core decide (level, sampling, ...) which cores to write
+	down := c.Core.Check(ent, nil)
+	if down == nil {
+		return ce
+	}
+	return ce.AddCore(ent, &checked{core: c, down: down})
+}
+
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) (err error) {
+	ent.Message = c.s.Scrub(ent.Message)
+	return c.Core.Write(ent, c.fields(fields))
+}
+
+// checked writes an entry to the cores chosen by wrapped core
+type checked struct {
+	*core
+	down *zapcore.CheckedEntry
+}
+
+func (c *checked) Write(ent zapcore.Entry, fields []zapcore.Field) (err error) {
+	c.down.Entry.Message = c.s.Scrub(ent.Message)
+	c.down.Write(c.fields(fields)...)
+	return
+}
@@ -0,0 +1,99 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package zapcard
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/raohwork/creditcard"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestField(t *testing.T) {
+	obs, logs := observer.New(zapcore.InfoLevel)
+	info, _ := creditcard.FromRaw("4111111111111111")
+	zap.New(obs).Info("charge", Field("card", info), Field("none", nil), Field("nocard", creditcard.NoCard))
+
+	entries := logs.AllUntimed()
+	if len(entries) != 1 {
+		t.Fatal("unexpected entries:", entries)
+	}
+	expect := map[string]interface{}{
+		"card": map[string]interface{}{
+			"pan":   "4111-11**-****-1111",
+			"brand": "visa",
+			"last4": "1111",
+		},
+	}
+	if actual := entries[0].ContextMap(); !reflect.DeepEqual(expect, actual) {
+		t.Log("expect:", expect)
+		t.Log("actual:", actual)
+		t.Fatal("unexpected result")
+	}
+}
+
+func TestWrapCore(t *testing.T) {
+	obs, logs := observer.New(zapcore.InfoLevel)
+	l := zap.New(WrapCore(obs, creditcard.ScrubSkipKeys("raw"))).
+		With(zap.String("session_pan", "5555555555554444"))
+
+	l.Debug("dropped 4111111111111111")
+	l.Info(
+		"charge 4111111111111111 for order 1234567890123456",
+		zap.String("pan", "4111 1111 1111 1111"),
+		zap.String("order_id", "1234567890123456"),
+		zap.String("raw", "4111111111111111"),
+		zap.Int64("amount", 4111111111111111),
+	)
+
+	entries := logs.AllUntimed()
+	if len(entries) != 1 {
+		t.Fatal("unexpected entries:", entries)
+	}
+	if entries[0].Level != zapcore.InfoLevel {
+		t.Fatal("unexpected level:", entries[0].Level)
+	}
+	expectMsg := "charge 411111******1111 for order 1234567890123456"
+	if entries[0].Message != expectMsg {
+		t.Log("expect:", expectMsg)
+		t.Log("actual:", entries[0].Message)
+		t.Fatal("unexpected message")
+	}
+	expect := map[string]interface{}{
+		"session_pan": "555555******4444",
+		"pan":         "4111 11** **** 1111",
+		"order_id":    "1234567890123456",
+		"raw":         "4111111111111111",
+		"amount":      int64(4111111111111111),
+	}
+	if actual := entries[0].ContextMap(); !reflect.DeepEqual(expect, actual) {
+		t.Log("expect:", expect)
+		t.Log("actual:", actual)
+		t.Fatal("unexpected fields")
+	}
+}
+
+func TestWrapCoreSampling(t *testing.T) {
+	obs, logs := observer.New(zapcore.InfoLevel)
+	sampled := zapcore.NewSamplerWithOptions(obs, time.Hour, 2, 0)
+	l := zap.New(WrapCore(sampled))
+	for i := 0; i < 5; i++ {
+		l.Info("card 4111111111111111")
+	}
+
+	entries := logs.AllUntimed()
+	if len(entries) != 2 {
+		t.Fatal("sampling is not preserved, entries:", len(entries))
+	}
+	for _, e := range entries {
+		if e.Message != "card 411111******1111" {
+			t.Fatal("unexpected message:", e.Message)
+		}
+	}
+}
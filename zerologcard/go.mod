@@ -0,0 +1,22 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+module github.com/raohwork/creditcard/zerologcard
+
+go 1.20
+
+require (
+	github.com/raohwork/creditcard v1.0.0
+	github.com/rs/zerolog v1.31.0
+)
+
+require (
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.19 // indirect
+	golang.org/x/sys v0.12.0 // indirect
+)
+
+// for developing in this repository only, replace directives are ignored
+// when this module is used as a dependency
+replace github.com/raohwork/creditcard => ../
@@ -0,0 +1,86 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+// Package zerologcard provides zerolog integration of creditcard package
+//
+// It lives in its own module so the main package stays dependency-free.
+//
+// A zerolog.Hook can only add fields to an event, the message and fields
+// already added cannot be changed. So PAN-like sequences are scrubbed by
+// wrapping the writer of zerolog.Logger with NewWriter instead.
+package zerologcard
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/raohwork/creditcard"
+	"github.com/rs/zerolog"
+)
+
+// Card wraps creditcard.Info so it can be logged without exposing raw PAN
+//
+// It logs masked PAN, brand and last 4 digits as an object, like
+// {"pan":"4111-11**-****-1111","brand":"visa","last4":"1111"}, no matter it
+// is logged by Event.Object or Event.Interface.
+type Card struct {
+	Info creditcard.Info
+}
+
+func (c Card) fields() (pan, brand, last4 string) {
+	if c.Info == nil {
+		return
+	}
+	brand = strings.SplitN(c.Info.AuditString(), "|", 2)[0]
+	return c.Info.Masked(), strings.ToLower(brand), c.Info.Last4()
+}
+
+// MarshalZerologObject implements zerolog.LogObjectMarshaler
+func (c Card) MarshalZerologObject(e *zerolog.Event) {
+	pan, brand, last4 := c.fields()
+	e.Str("pan", pan).Str("brand", brand).Str("last4", last4)
+}
+
+// MarshalJSON implements json.Marshaler
+func (c Card) MarshalJSON() (ret []byte, err error) {
+	pan, brand, last4 := c.fields()
+	return json.Marshal(map[string]string{
+		"pan":   pan,
+		"brand": brand,
+		"last4": last4,
+	})
+}
+
+type writer struct {
+	w io.Writer
+	s *creditcard.Scrubber
+}
+
+// NewWriter wraps w so PAN-like sequences in every logged event are masked
+// before written to w, see creditcard.Scrubber.ScrubJSON
+//
+// Use it as output of zerolog.Logger. To use with zerolog.ConsoleWriter, wrap
+// the ConsoleWriter since it expects JSON input.
+func NewWriter(w io.Writer, opts ...creditcard.ScrubOption) (ret zerolog.LevelWriter) {
+	return &writer{w: w, s: creditcard.NewScrubber(opts...)}
+}
+
+func (w *writer) Write(p []byte) (n int, err error) {
+	if _, err = w.w.Write(w.s.ScrubJSON(p)); err != nil {
+		return
+	}
+	return len(p), nil
+}
+
+func (w *writer) WriteLevel(l zerolog.Level, p []byte) (n int, err error) {
+	lw, ok := w.w.(zerolog.LevelWriter)
+	if !ok {
+		return w.Write(p)
+	}
+	if _, err = lw.WriteLevel(l, w.s.ScrubJSON(p)); err != nil {
+		return
+	}
+	return len(p), nil
+}
@@ -0,0 +1,80 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package zerologcard
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/raohwork/creditcard"
+	"github.com/rs/zerolog"
+)
+
+func decode(t *testing.T, b []byte) (ret map[string]interface{}) {
+	if err := json.Unmarshal(b, &ret); err != nil {
+		t.Log("output:", string(b))
+		t.Fatal("unexpected error:", err)
+	}
+	return
+}
+
+func TestCard(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := zerolog.New(buf)
+	info, _ := creditcard.FromRaw("4111111111111111")
+	l.Info().
+		Object("obj", Card{Info: info}).
+		Interface("iface", Card{Info: info}).
+		Interface("info", info).
+		Msg("")
+
+	if strings.Contains(buf.String(), "4111111111111111") {
+		t.Fatal("raw pan is logged:", buf.String())
+	}
+	card := map[string]interface{}{
+		"pan":   "4111-11**-****-1111",
+		"brand": "visa",
+		"last4": "1111",
+	}
+	expect := map[string]interface{}{
+		"level": "info",
+		"obj":   card,
+		"iface": card,
+		"info":  map[string]interface{}{},
+	}
+	if actual := decode(t, buf.Bytes()); !reflect.DeepEqual(expect, actual) {
+		t.Log("expect:", expect)
+		t.Log("actual:", actual)
+		t.Fatal("unexpected result")
+	}
+}
+
+func TestWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := zerolog.New(NewWriter(buf, creditcard.ScrubSkipKeys("raw")))
+	l.Warn().
+		Str("pan", "4111 1111 1111 1111").
+		Str("order_id", "1234567890123456").
+		Int64("amount", 4111111111111111).
+		Str("raw", "4111111111111111").
+		Msg("charge 5555555555554444")
+
+	expect := map[string]interface{}{
+		"level":    "warn",
+		"pan":      "4111 11** **** 1111",
+		"order_id": "1234567890123456",
+		"amount":   "411111******1111",
+		"raw":      "4111111111111111",
+		"message":  "charge 555555******4444",
+	}
+	if actual := decode(t, buf.Bytes()); !reflect.DeepEqual(expect, actual) {
+		t.Log("expect:", expect)
+		t.Log("actual:", actual)
+		t.Fatal("unexpected result")
+	}
+}